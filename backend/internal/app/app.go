@@ -13,6 +13,7 @@ import (
 	"gamecheck/internal/config"
 	"gamecheck/internal/handlers"
 	"gamecheck/internal/infra/db"
+	"gamecheck/internal/infra/realtime"
 	"gamecheck/internal/middleware"
 	"gamecheck/internal/services"
 
@@ -27,6 +28,7 @@ type App struct {
 	handlers *handlers.Handlers
 	services *services.Services
 	server   *http.Server
+	stop     context.CancelFunc
 }
 
 func New(cfg *config.Config) (*App, error) {
@@ -48,12 +50,19 @@ func New(cfg *config.Config) (*App, error) {
 		repos.User,
 		repos.Subscription,
 		repos.Activity,
+		repos.Review,
+		repos.Block,
+		repos.Mute,
 	)
 
 	steamService := services.NewSteamService(cfg)
 
+	notificationService := services.NewNotificationService(repos.Notification)
+
 	libraryService := services.NewLibraryService(
 		repos.Library,
+		repos.Series,
+		repos.User,
 		steamService,
 	)
 
@@ -62,11 +71,72 @@ func New(cfg *config.Config) (*App, error) {
 		repos.Activity,
 		steamService,
 		libraryService,
+		notificationService,
 	)
 
 	activityService := services.NewActivityService(
 		repos.Activity,
 		repos.User,
+		repos.Comment,
+		notificationService,
+	)
+
+	reviewService := services.NewReviewService(
+		repos.Review,
+		repos.Progress,
+		repos.Block,
+	)
+
+	realtimeService := services.NewRealtimeService(
+		realtime.NewHub(),
+		repos.Activity,
+		repos.Subscription,
+		repos.Notification,
+	)
+
+	syndicationService := services.NewSyndicationService(
+		cfg,
+		activityService,
+		repos.User,
+		repos.Library,
+	)
+
+	webhookService := services.NewWebhookService(
+		cfg,
+		repos.Webhook,
+		repos.Activity,
+		repos.User,
+	)
+
+	retentionService := services.NewRetentionService(cfg, repos.Activity)
+
+	compatibilityService := services.NewCompatibilityService(repos.Progress)
+
+	recommendationService := services.NewRecommendationService(
+		repos.Recommendation,
+		repos.Library,
+	)
+
+	similarGameService := services.NewSimilarGameService(
+		repos.SimilarGame,
+		repos.Library,
+		libraryService,
+	)
+
+	searchService := services.NewSearchService(repos.Search)
+
+	chartService := services.NewChartService(repos.Chart)
+
+	seriesService := services.NewSeriesService(
+		repos.Series,
+		repos.Library,
+	)
+
+	progressImportService := services.NewProgressImportService(
+		repos.ProgressImport,
+		repos.Progress,
+		libraryService,
+		steamService,
 	)
 
 	svcs := services.New(
@@ -76,18 +146,40 @@ func New(cfg *config.Config) (*App, error) {
 		activityService,
 		libraryService,
 		steamService,
+		reviewService,
+		notificationService,
+		realtimeService,
+		syndicationService,
+		webhookService,
+		compatibilityService,
+		recommendationService,
+		similarGameService,
+		searchService,
+		chartService,
+		seriesService,
+		progressImportService,
 	)
 
 	hdlrs := handlers.New(cfg, svcs, repos.Repository)
 
 	router := setupRouter(cfg, hdlrs)
 
+	ctx, stop := context.WithCancel(context.Background())
+	go database.Listen(ctx, db.RealtimeChannel, realtimeService.HandlePayload)
+	go retentionService.Run(ctx)
+	go webhookService.Run(ctx)
+	go recommendationService.Run(ctx)
+	go similarGameService.Run(ctx)
+	go chartService.Run(ctx)
+	go progressImportService.Run(ctx)
+
 	app := &App{
 		config:   cfg,
 		router:   router,
 		database: database,
 		handlers: hdlrs,
 		services: svcs,
+		stop:     stop,
 	}
 
 	return app, nil
@@ -103,7 +195,7 @@ func setupRouter(cfg *config.Config, hdlrs *handlers.Handlers) *gin.Engine {
 	corsConfig := cors.Config{
 		AllowOrigins:     []string{cfg.URLS.Frontend},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "Cookie", "X-Auth-Check", "X-User-ID"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "Cookie", "X-Auth-Check", "X-User-ID", "Last-Event-ID"},
 		ExposeHeaders:    []string{"Content-Length", "Set-Cookie"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
@@ -148,6 +240,9 @@ func (a *App) Run() error {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
+	a.stop()
+	a.services.Realtime.Close()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +18,8 @@ type Config struct {
 	Database DatabaseConfig
 	Steam    SteamConfig
 	CORS     CORSConfig
+	Admin    AdminConfig
+	Activity ActivityRetentionConfig
 }
 
 type Urls struct {
@@ -44,6 +49,17 @@ type CORSConfig struct {
 	Origins []string
 }
 
+type AdminConfig struct {
+	SteamIDs []string
+}
+
+type ActivityRetentionConfig struct {
+	MaxPerUser int
+	MaxAge     time.Duration
+	Archive    bool
+	Interval   time.Duration
+}
+
 func Load() (*Config, error) {
 	godotenv.Load()
 
@@ -72,8 +88,17 @@ func Load() (*Config, error) {
 			APIKey:      os.Getenv("STEAM_API_KEY"),
 			RedirectURI: backendURL + os.Getenv("STEAM_REDIRECT_URI"),
 		},
+		Admin: AdminConfig{
+			SteamIDs: splitList(os.Getenv("ADMIN_STEAM_IDS")),
+		},
 	}
 
+	retention, err := loadActivityRetention()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Activity = retention
+
 	if err := cfg.validate(); err != nil {
 		return nil, err
 	}
@@ -96,3 +121,63 @@ func (c *Config) validate() error {
 	}
 	return nil
 }
+
+func loadActivityRetention() (ActivityRetentionConfig, error) {
+	retention := ActivityRetentionConfig{
+		Interval: time.Hour,
+	}
+
+	if value := os.Getenv("ACTIVITY_RETENTION_MAX_PER_USER"); value != "" {
+		maxPerUser, err := strconv.Atoi(value)
+		if err != nil || maxPerUser < 0 {
+			return retention, fmt.Errorf("ACTIVITY_RETENTION_MAX_PER_USER must be a non-negative integer")
+		}
+		retention.MaxPerUser = maxPerUser
+	}
+
+	if value := os.Getenv("ACTIVITY_RETENTION_MAX_AGE"); value != "" {
+		maxAge, err := time.ParseDuration(value)
+		if err != nil || maxAge < 0 {
+			return retention, fmt.Errorf("ACTIVITY_RETENTION_MAX_AGE must be a non-negative duration")
+		}
+		retention.MaxAge = maxAge
+	}
+
+	if value := os.Getenv("ACTIVITY_RETENTION_ARCHIVE"); value != "" {
+		archive, err := strconv.ParseBool(value)
+		if err != nil {
+			return retention, fmt.Errorf("ACTIVITY_RETENTION_ARCHIVE must be a boolean")
+		}
+		retention.Archive = archive
+	}
+
+	if value := os.Getenv("ACTIVITY_RETENTION_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return retention, fmt.Errorf("ACTIVITY_RETENTION_INTERVAL must be a positive duration")
+		}
+		retention.Interval = interval
+	}
+
+	return retention, nil
+}
+
+func (c *Config) IsAdminSteamID(steamID string) bool {
+	for _, id := range c.Admin.SteamIDs {
+		if id == steamID {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(value string) []string {
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -38,3 +38,16 @@ func (a *Activity) BeforeCreate(tx *gorm.DB) error {
 	a.CreatedAt = time.Now()
 	return nil
 }
+
+type ArchivedActivity struct {
+	ID           string       `json:"id" gorm:"type:uuid;primary_key"`
+	UserID       string       `json:"userId" gorm:"type:uuid;index:idx_archived_activity_user_created,priority:1"`
+	Type         ActivityType `json:"type"`
+	ProgressID   *string      `json:"progressId,omitempty" gorm:"type:uuid;default:null"`
+	TargetUserID *string      `json:"targetUserId,omitempty" gorm:"type:uuid;default:null"`
+	GameName     *string      `json:"gameName,omitempty" gorm:"default:null"`
+	Status       *GameStatus  `json:"status,omitempty" gorm:"default:null"`
+	Rating       *int         `json:"rating,omitempty" gorm:"default:null"`
+	CreatedAt    time.Time    `json:"createdAt" gorm:"index:idx_archived_activity_user_created,priority:2"`
+	ArchivedAt   time.Time    `json:"archivedAt"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ActivityLike struct {
+	ID         string    `json:"id" gorm:"type:uuid;primary_key"`
+	ActivityID string    `json:"activityId" gorm:"type:uuid;not null;uniqueIndex:unique_activity_like"`
+	Activity   Activity  `json:"-" gorm:"foreignKey:ActivityID;constraint:OnDelete:CASCADE"`
+	UserID     string    `json:"userId" gorm:"type:uuid;not null;index;uniqueIndex:unique_activity_like"`
+	User       User      `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func (l *ActivityLike) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	l.CreatedAt = time.Now()
+	return nil
+}
+
+type ActivityComment struct {
+	ID         string           `json:"id" gorm:"type:uuid;primary_key"`
+	ActivityID string           `json:"activityId" gorm:"type:uuid;not null;index:idx_activity_comment_activity_created,priority:1"`
+	Activity   Activity         `json:"-" gorm:"foreignKey:ActivityID;constraint:OnDelete:CASCADE"`
+	UserID     string           `json:"userId" gorm:"type:uuid;not null;index"`
+	User       User             `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	ParentID   *string          `json:"parentId,omitempty" gorm:"type:uuid;index;default:null"`
+	Parent     *ActivityComment `json:"-" gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE"`
+	Body       string           `json:"body" gorm:"type:text;not null"`
+	EditedAt   *time.Time       `json:"editedAt,omitempty" gorm:"default:null"`
+	CreatedAt  time.Time        `json:"createdAt" gorm:"index:idx_activity_comment_activity_created,priority:2"`
+}
+
+func (c *ActivityComment) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	c.CreatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserBlock struct {
+	ID        string    `json:"id" gorm:"type:uuid;primary_key"`
+	BlockerID string    `json:"blockerId" gorm:"type:uuid;not null;uniqueIndex:unique_user_block"`
+	Blocker   User      `json:"-" gorm:"foreignKey:BlockerID;constraint:OnDelete:CASCADE"`
+	BlockedID string    `json:"blockedId" gorm:"type:uuid;not null;index;uniqueIndex:unique_user_block"`
+	Blocked   User      `json:"-" gorm:"foreignKey:BlockedID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (b *UserBlock) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = uuid.New().String()
+	}
+	b.CreatedAt = time.Now()
+	return nil
+}
+
+type UserMute struct {
+	ID        string    `json:"id" gorm:"type:uuid;primary_key"`
+	MuterID   string    `json:"muterId" gorm:"type:uuid;not null;uniqueIndex:unique_user_mute"`
+	Muter     User      `json:"-" gorm:"foreignKey:MuterID;constraint:OnDelete:CASCADE"`
+	MutedID   string    `json:"mutedId" gorm:"type:uuid;not null;index;uniqueIndex:unique_user_mute"`
+	Muted     User      `json:"-" gorm:"foreignKey:MutedID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (m *UserMute) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	m.CreatedAt = time.Now()
+	return nil
+}
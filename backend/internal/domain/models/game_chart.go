@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type ChartType string
+
+const (
+	ChartTypeTrending    ChartType = "trending"
+	ChartTypeTopRated    ChartType = "top_rated"
+	ChartTypeMostDropped ChartType = "most_dropped"
+)
+
+type ChartWindow string
+
+const (
+	ChartWindowWeek  ChartWindow = "7d"
+	ChartWindowMonth ChartWindow = "30d"
+	ChartWindowAll   ChartWindow = "all"
+)
+
+type GameChartEntry struct {
+	ChartType     ChartType   `json:"chartType" gorm:"primaryKey;index:idx_game_chart_entries_rank,priority:1"`
+	Window        ChartWindow `json:"window" gorm:"column:time_window;primaryKey;index:idx_game_chart_entries_rank,priority:2"`
+	GameID        string      `json:"gameId" gorm:"type:uuid;primaryKey"`
+	Game          LibraryGame `json:"-" gorm:"foreignKey:GameID;constraint:OnDelete:CASCADE"`
+	Score         float64     `json:"score" gorm:"not null;index:idx_game_chart_entries_rank,priority:3,sort:desc"`
+	EventCount    int         `json:"eventCount" gorm:"not null"`
+	AverageRating *float64    `json:"averageRating,omitempty" gorm:"default:null"`
+	ComputedAt    time.Time   `json:"computedAt" gorm:"not null"`
+}
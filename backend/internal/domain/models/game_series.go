@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GameSeriesSource string
+
+const (
+	GameSeriesSourceSteam   GameSeriesSource = "steam"
+	GameSeriesSourceCurated GameSeriesSource = "curated"
+)
+
+type GameSeries struct {
+	ID          string           `json:"id" gorm:"type:uuid;primary_key"`
+	Name        string           `json:"name" gorm:"not null"`
+	Slug        string           `json:"slug" gorm:"not null;uniqueIndex"`
+	Description string           `json:"description,omitempty" gorm:"type:text"`
+	Source      GameSeriesSource `json:"source" gorm:"not null;default:'curated'"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+func (s *GameSeries) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	if s.Source == "" {
+		s.Source = GameSeriesSourceCurated
+	}
+	now := time.Now()
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = now
+	}
+	s.UpdatedAt = now
+	return nil
+}
+
+func (s *GameSeries) BeforeUpdate(tx *gorm.DB) error {
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+type GameSeriesEntry struct {
+	SeriesID string      `json:"seriesId" gorm:"type:uuid;primaryKey;index:idx_game_series_entries_position,priority:1"`
+	Series   GameSeries  `json:"-" gorm:"foreignKey:SeriesID;constraint:OnDelete:CASCADE"`
+	GameID   string      `json:"gameId" gorm:"type:uuid;primaryKey;index"`
+	Game     LibraryGame `json:"-" gorm:"foreignKey:GameID;constraint:OnDelete:CASCADE"`
+	Position int         `json:"position" gorm:"not null;index:idx_game_series_entries_position,priority:2"`
+}
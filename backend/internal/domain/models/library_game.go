@@ -7,28 +7,57 @@ import (
 	"gorm.io/gorm"
 )
 
+type LibraryGameSource string
+
+const (
+	LibraryGameSourceSteam LibraryGameSource = "steam"
+	LibraryGameSourceUser  LibraryGameSource = "user"
+)
+
+type LibraryGameModerationStatus string
+
+const (
+	LibraryGameModerationPending  LibraryGameModerationStatus = "pending"
+	LibraryGameModerationApproved LibraryGameModerationStatus = "approved"
+	LibraryGameModerationRejected LibraryGameModerationStatus = "rejected"
+)
+
 type LibraryGame struct {
-	ID               string    `json:"id" gorm:"type:uuid;primary_key"`
-	SteamAppID       int       `json:"steamAppId" gorm:"uniqueIndex;not null"`
-	Name             string    `json:"name"`
-	ShortDescription string    `json:"shortDescription" gorm:"type:text"`
-	Description      string    `json:"description" gorm:"type:text"`
-	HeaderImage      string    `json:"headerImage"`
-	CapsuleImage     string    `json:"capsuleImage"`
-	BackgroundImage  string    `json:"backgroundImage"`
-	StoreURL         string    `json:"storeUrl"`
-	PrimaryGenre     string    `json:"primaryGenre"`
-	Genres           []string  `json:"genres" gorm:"type:jsonb;serializer:json"`
-	Categories       []string  `json:"categories" gorm:"type:jsonb;serializer:json"`
-	Tags             []string  `json:"tags" gorm:"type:jsonb;serializer:json"`
-	CreatedAt        time.Time `json:"createdAt"`
-	UpdatedAt        time.Time `json:"updatedAt"`
+	ID               string                      `json:"id" gorm:"type:uuid;primary_key"`
+	SteamAppID       *int                        `json:"steamAppId,omitempty" gorm:"uniqueIndex;default:null"`
+	Name             string                      `json:"name"`
+	ShortDescription string                      `json:"shortDescription" gorm:"type:text"`
+	Description      string                      `json:"description" gorm:"type:text"`
+	HeaderImage      string                      `json:"headerImage"`
+	CapsuleImage     string                      `json:"capsuleImage"`
+	BackgroundImage  string                      `json:"backgroundImage"`
+	StoreURL         string                      `json:"storeUrl"`
+	PrimaryGenre     string                      `json:"primaryGenre"`
+	Genres           []string                    `json:"genres" gorm:"type:jsonb;serializer:json;index:idx_library_games_genres,type:gin"`
+	Categories       []string                    `json:"categories" gorm:"type:jsonb;serializer:json;index:idx_library_games_categories,type:gin"`
+	Tags             []string                    `json:"tags" gorm:"type:jsonb;serializer:json;index:idx_library_games_tags,type:gin"`
+	Platform         string                      `json:"platform,omitempty"`
+	ReleaseYear      *int                        `json:"releaseYear,omitempty" gorm:"default:null"`
+	Source           LibraryGameSource           `json:"source" gorm:"not null;default:'steam'"`
+	ModerationStatus LibraryGameModerationStatus `json:"moderationStatus" gorm:"not null;default:'approved';index"`
+	ModerationNote   string                      `json:"moderationNote,omitempty" gorm:"type:text"`
+	SubmittedByID    *string                     `json:"submittedById,omitempty" gorm:"type:uuid;default:null;index"`
+	SubmittedBy      *User                       `json:"-" gorm:"foreignKey:SubmittedByID;constraint:OnDelete:SET NULL"`
+	ModeratedAt      *time.Time                  `json:"moderatedAt,omitempty" gorm:"default:null"`
+	CreatedAt        time.Time                   `json:"createdAt"`
+	UpdatedAt        time.Time                   `json:"updatedAt"`
 }
 
 func (l *LibraryGame) BeforeCreate(tx *gorm.DB) error {
 	if l.ID == "" {
 		l.ID = uuid.New().String()
 	}
+	if l.Source == "" {
+		l.Source = LibraryGameSourceSteam
+	}
+	if l.ModerationStatus == "" {
+		l.ModerationStatus = LibraryGameModerationApproved
+	}
 	now := time.Now()
 	if l.CreatedAt.IsZero() {
 		l.CreatedAt = now
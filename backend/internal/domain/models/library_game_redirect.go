@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type LibraryGameRedirect struct {
+	ID         string      `json:"id" gorm:"type:uuid;primary_key"`
+	SteamAppID *int        `json:"steamAppId,omitempty" gorm:"uniqueIndex;default:null"`
+	TargetID   string      `json:"targetId" gorm:"type:uuid;not null;index"`
+	Target     LibraryGame `json:"-" gorm:"foreignKey:TargetID;constraint:OnDelete:CASCADE"`
+	CreatedAt  time.Time   `json:"createdAt"`
+}
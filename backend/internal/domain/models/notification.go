@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeNewFollower     NotificationType = "new_follower"
+	NotificationTypeActivityLike    NotificationType = "activity_like"
+	NotificationTypeActivityComment NotificationType = "activity_comment"
+	NotificationTypeCommentReply    NotificationType = "comment_reply"
+	NotificationTypeFollowedGame    NotificationType = "followed_game_added"
+	NotificationTypeFollowRequest   NotificationType = "follow_request"
+	NotificationTypeFollowApproved  NotificationType = "follow_request_approved"
+)
+
+var NotificationTypes = []NotificationType{
+	NotificationTypeNewFollower,
+	NotificationTypeActivityLike,
+	NotificationTypeActivityComment,
+	NotificationTypeCommentReply,
+	NotificationTypeFollowedGame,
+	NotificationTypeFollowRequest,
+	NotificationTypeFollowApproved,
+}
+
+func (t NotificationType) IsValid() bool {
+	for _, known := range NotificationTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+type Notification struct {
+	ID         string           `json:"id" gorm:"type:uuid;primary_key"`
+	UserID     string           `json:"userId" gorm:"type:uuid;not null;index:idx_notification_user_created,priority:1"`
+	User       User             `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	ActorID    *string          `json:"actorId,omitempty" gorm:"type:uuid;default:null"`
+	Actor      *User            `json:"-" gorm:"foreignKey:ActorID;constraint:OnDelete:CASCADE"`
+	Type       NotificationType `json:"type" gorm:"not null"`
+	ActivityID *string          `json:"activityId,omitempty" gorm:"type:uuid;index;default:null"`
+	Activity   *Activity        `json:"-" gorm:"foreignKey:ActivityID;constraint:OnDelete:CASCADE"`
+	CommentID  *string          `json:"commentId,omitempty" gorm:"type:uuid;index;default:null"`
+	Comment    *ActivityComment `json:"-" gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE"`
+	GameName   *string          `json:"gameName,omitempty" gorm:"default:null"`
+	ReadAt     *time.Time       `json:"readAt,omitempty" gorm:"default:null"`
+	CreatedAt  time.Time        `json:"createdAt" gorm:"index:idx_notification_user_created,priority:2"`
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == "" {
+		n.ID = uuid.New().String()
+	}
+	n.CreatedAt = time.Now()
+	return nil
+}
+
+type NotificationPreference struct {
+	UserID  string           `json:"-" gorm:"type:uuid;primaryKey"`
+	User    User             `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Type    NotificationType `json:"type" gorm:"primaryKey"`
+	Enabled bool             `json:"enabled" gorm:"not null"`
+}
@@ -0,0 +1,89 @@
+package models
+
+type GamePlatform struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Family string `json:"family"`
+}
+
+type GameStore struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type OwnershipType string
+
+const (
+	OwnershipTypeOwned        OwnershipType = "owned"
+	OwnershipTypeSubscription OwnershipType = "subscription"
+	OwnershipTypeBorrowed     OwnershipType = "borrowed"
+	OwnershipTypeWishlist     OwnershipType = "wishlist"
+)
+
+var OwnershipTypes = []OwnershipType{
+	OwnershipTypeOwned,
+	OwnershipTypeSubscription,
+	OwnershipTypeBorrowed,
+	OwnershipTypeWishlist,
+}
+
+var GamePlatforms = []GamePlatform{
+	{ID: "pc", Name: "PC", Family: "pc"},
+	{ID: "mac", Name: "macOS", Family: "pc"},
+	{ID: "linux", Name: "Linux", Family: "pc"},
+	{ID: "steam_deck", Name: "Steam Deck", Family: "pc"},
+	{ID: "ps5", Name: "PlayStation 5", Family: "playstation"},
+	{ID: "ps4", Name: "PlayStation 4", Family: "playstation"},
+	{ID: "xbox_series", Name: "Xbox Series X|S", Family: "xbox"},
+	{ID: "xbox_one", Name: "Xbox One", Family: "xbox"},
+	{ID: "switch", Name: "Nintendo Switch", Family: "nintendo"},
+	{ID: "ios", Name: "iOS", Family: "mobile"},
+	{ID: "android", Name: "Android", Family: "mobile"},
+	{ID: "other", Name: "Other", Family: "other"},
+}
+
+var GameStores = []GameStore{
+	{ID: "steam", Name: "Steam"},
+	{ID: "epic", Name: "Epic Games Store"},
+	{ID: "gog", Name: "GOG"},
+	{ID: "ea_app", Name: "EA app"},
+	{ID: "ubisoft_connect", Name: "Ubisoft Connect"},
+	{ID: "battle_net", Name: "Battle.net"},
+	{ID: "microsoft_store", Name: "Microsoft Store"},
+	{ID: "game_pass", Name: "Xbox Game Pass"},
+	{ID: "playstation_store", Name: "PlayStation Store"},
+	{ID: "ps_plus", Name: "PlayStation Plus"},
+	{ID: "nintendo_eshop", Name: "Nintendo eShop"},
+	{ID: "app_store", Name: "App Store"},
+	{ID: "google_play", Name: "Google Play"},
+	{ID: "itch", Name: "itch.io"},
+	{ID: "physical", Name: "Physical copy"},
+	{ID: "other", Name: "Other"},
+}
+
+func (o OwnershipType) IsValid() bool {
+	for _, value := range OwnershipTypes {
+		if o == value {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidPlatform(id string) bool {
+	for _, platform := range GamePlatforms {
+		if platform.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidStore(id string) bool {
+	for _, store := range GameStores {
+		if store.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -9,18 +9,31 @@ import (
 
 type GameStatus string
 
+const (
+	GameStatusPlanToPlay GameStatus = "plan_to_play"
+	GameStatusPlaying    GameStatus = "playing"
+	GameStatusCompleted  GameStatus = "completed"
+	GameStatusDropped    GameStatus = "dropped"
+)
+
 type Progress struct {
-	ID                   string     `json:"id" gorm:"type:uuid;primary_key"`
-	UserID               string     `json:"userId" gorm:"type:uuid;index;not null;index:idx_progress_user_status,priority:1"`
-	User                 User       `json:"user" gorm:"foreignKey:UserID"`
-	Name                 string     `json:"name" gorm:"not null"`
-	Status               GameStatus `json:"status" gorm:"not null;index:idx_progress_user_status,priority:2"`
-	Rating               *int       `json:"rating,omitempty" gorm:"default:null"`
-	Review               string     `json:"review,omitempty" gorm:"type:text;default:null"`
-	SteamAppID           *int       `json:"steamAppId,omitempty" gorm:"default:null;index"`
-	SteamPlaytimeForever *int       `json:"steamPlaytimeForever,omitempty" gorm:"default:null"`
-	CreatedAt            time.Time  `json:"createdAt"`
-	UpdatedAt            time.Time  `json:"updatedAt"`
+	ID                   string            `json:"id" gorm:"type:uuid;primary_key"`
+	UserID               string            `json:"userId" gorm:"type:uuid;index;not null;index:idx_progress_user_status,priority:1"`
+	User                 User              `json:"user" gorm:"foreignKey:UserID"`
+	Name                 string            `json:"name" gorm:"not null"`
+	Status               GameStatus        `json:"status" gorm:"not null;index:idx_progress_user_status,priority:2"`
+	Rating               *int              `json:"rating,omitempty" gorm:"default:null"`
+	Review               string            `json:"review,omitempty" gorm:"type:text;default:null"`
+	SteamAppID           *int              `json:"steamAppId,omitempty" gorm:"default:null;index"`
+	LibraryGameID        *string           `json:"libraryGameId,omitempty" gorm:"type:uuid;default:null;index"`
+	LibraryGame          *LibraryGame      `json:"-" gorm:"foreignKey:LibraryGameID;constraint:OnDelete:SET NULL"`
+	SteamPlaytimeForever *int              `json:"steamPlaytimeForever,omitempty" gorm:"default:null"`
+	Platform             string            `json:"platform,omitempty" gorm:"default:null;index"`
+	Ownership            OwnershipType     `json:"ownership,omitempty" gorm:"default:null;index"`
+	Store                string            `json:"store,omitempty" gorm:"default:null"`
+	Visibility           ProfileVisibility `json:"visibility" gorm:"not null;default:'public';index"`
+	CreatedAt            time.Time         `json:"createdAt"`
+	UpdatedAt            time.Time         `json:"updatedAt"`
 }
 
 func (p *Progress) BeforeCreate(tx *gorm.DB) error {
@@ -28,6 +41,9 @@ func (p *Progress) BeforeCreate(tx *gorm.DB) error {
 		p.ID = uuid.New().String()
 	}
 	now := time.Now()
+	if p.Visibility == "" {
+		p.Visibility = ProfileVisibilityPublic
+	}
 	if p.CreatedAt.IsZero() {
 		p.CreatedAt = now
 	}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProgressImportStatus string
+
+const (
+	ProgressImportPending   ProgressImportStatus = "pending"
+	ProgressImportRunning   ProgressImportStatus = "running"
+	ProgressImportCompleted ProgressImportStatus = "completed"
+	ProgressImportFailed    ProgressImportStatus = "failed"
+)
+
+type ProgressImportDuplicateMode string
+
+const (
+	ProgressImportSkip      ProgressImportDuplicateMode = "skip"
+	ProgressImportOverwrite ProgressImportDuplicateMode = "overwrite"
+	ProgressImportMerge     ProgressImportDuplicateMode = "merge"
+)
+
+func (m ProgressImportDuplicateMode) IsValid() bool {
+	switch m {
+	case ProgressImportSkip, ProgressImportOverwrite, ProgressImportMerge:
+		return true
+	default:
+		return false
+	}
+}
+
+type ProgressImportAction string
+
+const (
+	ProgressImportCreated ProgressImportAction = "created"
+	ProgressImportUpdated ProgressImportAction = "updated"
+	ProgressImportSkipped ProgressImportAction = "skipped"
+	ProgressImportError   ProgressImportAction = "failed"
+)
+
+type ProgressImportRow struct {
+	Line          int      `json:"line"`
+	Name          string   `json:"name,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	Rating        string   `json:"rating,omitempty"`
+	Review        string   `json:"review,omitempty"`
+	SteamAppID    string   `json:"steamAppId,omitempty"`
+	LibraryGameID string   `json:"libraryGameId,omitempty"`
+	Platform      string   `json:"platform,omitempty"`
+	Ownership     string   `json:"ownership,omitempty"`
+	Store         string   `json:"store,omitempty"`
+	Visibility    string   `json:"visibility,omitempty"`
+	Fields        []string `json:"fields,omitempty"`
+}
+
+type ProgressImportResult struct {
+	Line          int                  `json:"line"`
+	Name          string               `json:"name,omitempty"`
+	Action        ProgressImportAction `json:"action"`
+	ProgressID    *string              `json:"progressId,omitempty"`
+	SteamAppID    *int                 `json:"steamAppId,omitempty"`
+	LibraryGameID *string              `json:"libraryGameId,omitempty"`
+	Error         string               `json:"error,omitempty"`
+}
+
+type ProgressImportJob struct {
+	ID            string                      `json:"id" gorm:"type:uuid;primary_key"`
+	UserID        string                      `json:"userId" gorm:"type:uuid;not null;index"`
+	User          User                        `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Status        ProgressImportStatus        `json:"status" gorm:"not null;default:pending;index"`
+	Format        string                      `json:"format" gorm:"not null"`
+	Duplicates    ProgressImportDuplicateMode `json:"duplicates" gorm:"not null"`
+	TotalRows     int                         `json:"totalRows" gorm:"not null"`
+	ProcessedRows int                         `json:"processedRows" gorm:"not null;default:0"`
+	CreatedCount  int                         `json:"createdCount" gorm:"not null;default:0"`
+	UpdatedCount  int                         `json:"updatedCount" gorm:"not null;default:0"`
+	SkippedCount  int                         `json:"skippedCount" gorm:"not null;default:0"`
+	FailedCount   int                         `json:"failedCount" gorm:"not null;default:0"`
+	Rows          []ProgressImportRow         `json:"-" gorm:"type:jsonb;serializer:json"`
+	Results       []ProgressImportResult      `json:"results" gorm:"type:jsonb;serializer:json"`
+	Error         string                      `json:"error,omitempty" gorm:"type:text"`
+	CreatedAt     time.Time                   `json:"createdAt"`
+	UpdatedAt     time.Time                   `json:"updatedAt"`
+	FinishedAt    *time.Time                  `json:"finishedAt,omitempty" gorm:"default:null"`
+}
+
+func (j *ProgressImportJob) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == "" {
+		j.ID = uuid.New().String()
+	}
+	if j.Status == "" {
+		j.Status = ProgressImportPending
+	}
+	now := time.Now()
+	j.CreatedAt = now
+	j.UpdatedAt = now
+	return nil
+}
+
+func (j *ProgressImportJob) BeforeUpdate(tx *gorm.DB) error {
+	j.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RecommendationSource string
+
+const (
+	RecommendationSourceCollaborative RecommendationSource = "collaborative"
+	RecommendationSourceContent       RecommendationSource = "content"
+	RecommendationSourcePopular       RecommendationSource = "popular"
+)
+
+type Recommendation struct {
+	ID            string               `json:"id" gorm:"type:uuid;primary_key"`
+	UserID        string               `json:"userId" gorm:"type:uuid;not null;uniqueIndex:unique_recommendation"`
+	User          User                 `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	SteamAppID    int                  `json:"steamAppId" gorm:"not null;uniqueIndex:unique_recommendation"`
+	Score         float64              `json:"score" gorm:"not null"`
+	Source        RecommendationSource `json:"source" gorm:"not null"`
+	Reason        string               `json:"reason" gorm:"type:text"`
+	BasedOnAppID  *int                 `json:"basedOnAppId,omitempty" gorm:"default:null"`
+	BasedOnRating *int                 `json:"basedOnRating,omitempty" gorm:"default:null"`
+	ComputedAt    time.Time            `json:"computedAt"`
+}
+
+func (r *Recommendation) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	if r.ComputedAt.IsZero() {
+		r.ComputedAt = time.Now()
+	}
+	return nil
+}
+
+type RecommendationRun struct {
+	UserID     string    `json:"userId" gorm:"type:uuid;primary_key"`
+	User       User      `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	ComputedAt time.Time `json:"computedAt" gorm:"not null"`
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReviewReportReason string
+
+const (
+	ReviewReportReasonSpam      ReviewReportReason = "spam"
+	ReviewReportReasonOffensive ReviewReportReason = "offensive"
+	ReviewReportReasonSpoiler   ReviewReportReason = "spoiler"
+	ReviewReportReasonOffTopic  ReviewReportReason = "off_topic"
+	ReviewReportReasonOther     ReviewReportReason = "other"
+)
+
+func (r ReviewReportReason) IsValid() bool {
+	switch r {
+	case ReviewReportReasonSpam,
+		ReviewReportReasonOffensive,
+		ReviewReportReasonSpoiler,
+		ReviewReportReasonOffTopic,
+		ReviewReportReasonOther:
+		return true
+	}
+	return false
+}
+
+type ReviewReportStatus string
+
+const (
+	ReviewReportStatusPending   ReviewReportStatus = "pending"
+	ReviewReportStatusResolved  ReviewReportStatus = "resolved"
+	ReviewReportStatusDismissed ReviewReportStatus = "dismissed"
+)
+
+type ReviewVote struct {
+	ID         string    `json:"id" gorm:"type:uuid;primary_key"`
+	ProgressID string    `json:"progressId" gorm:"type:uuid;not null;uniqueIndex:unique_review_vote"`
+	Progress   Progress  `json:"-" gorm:"foreignKey:ProgressID;constraint:OnDelete:CASCADE"`
+	UserID     string    `json:"userId" gorm:"type:uuid;not null;index;uniqueIndex:unique_review_vote"`
+	User       User      `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Helpful    bool      `json:"helpful" gorm:"not null"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func (v *ReviewVote) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == "" {
+		v.ID = uuid.New().String()
+	}
+	now := time.Now()
+	v.CreatedAt = now
+	v.UpdatedAt = now
+	return nil
+}
+
+func (v *ReviewVote) BeforeUpdate(tx *gorm.DB) error {
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+type ReviewReport struct {
+	ID           string             `json:"id" gorm:"type:uuid;primary_key"`
+	ProgressID   string             `json:"progressId" gorm:"type:uuid;not null;uniqueIndex:unique_review_report"`
+	Progress     Progress           `json:"-" gorm:"foreignKey:ProgressID;constraint:OnDelete:CASCADE"`
+	ReporterID   string             `json:"reporterId" gorm:"type:uuid;not null;uniqueIndex:unique_review_report"`
+	Reporter     User               `json:"-" gorm:"foreignKey:ReporterID;constraint:OnDelete:CASCADE"`
+	Reason       ReviewReportReason `json:"reason" gorm:"not null"`
+	Details      string             `json:"details,omitempty" gorm:"type:text;default:null"`
+	Status       ReviewReportStatus `json:"status" gorm:"not null;default:pending;index"`
+	ResolvedByID *string            `json:"resolvedById,omitempty" gorm:"type:uuid;default:null"`
+	ResolvedAt   *time.Time         `json:"resolvedAt,omitempty" gorm:"default:null"`
+	CreatedAt    time.Time          `json:"createdAt"`
+}
+
+func (r *ReviewReport) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	if r.Status == "" {
+		r.Status = ReviewReportStatusPending
+	}
+	r.CreatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type SimilarGame struct {
+	GameID        string      `json:"gameId" gorm:"type:uuid;primaryKey;index:idx_similar_games_rank,priority:1"`
+	Game          LibraryGame `json:"-" gorm:"foreignKey:GameID;constraint:OnDelete:CASCADE"`
+	SimilarGameID string      `json:"similarGameId" gorm:"type:uuid;primaryKey"`
+	SimilarGame   LibraryGame `json:"-" gorm:"foreignKey:SimilarGameID;constraint:OnDelete:CASCADE"`
+	Score         float64     `json:"score" gorm:"not null;index:idx_similar_games_rank,priority:2,sort:desc"`
+	TagScore      float64     `json:"tagScore" gorm:"not null"`
+	CoRatedScore  float64     `json:"coRatedScore" gorm:"not null"`
+	ComputedAt    time.Time   `json:"computedAt" gorm:"not null"`
+}
+
+type SimilarGameRun struct {
+	GameID     string      `json:"gameId" gorm:"type:uuid;primary_key"`
+	Game       LibraryGame `json:"-" gorm:"foreignKey:GameID;constraint:OnDelete:CASCADE"`
+	ComputedAt time.Time   `json:"computedAt" gorm:"not null"`
+}
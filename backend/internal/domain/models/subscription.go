@@ -7,13 +7,21 @@ import (
 	"gorm.io/gorm"
 )
 
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusAccepted SubscriptionStatus = "accepted"
+	SubscriptionStatusPending  SubscriptionStatus = "pending"
+)
+
 type Subscription struct {
-	ID          string    `json:"id" gorm:"type:uuid;primary_key"`
-	FollowerID  string    `json:"followerId" gorm:"type:uuid;index;uniqueIndex:unique_subscription;not null"`
-	Follower    User      `json:"follower" gorm:"foreignKey:FollowerID"`
-	FollowingID string    `json:"followingId" gorm:"type:uuid;index;uniqueIndex:unique_subscription;not null"`
-	Following   User      `json:"following" gorm:"foreignKey:FollowingID"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID          string             `json:"id" gorm:"type:uuid;primary_key"`
+	FollowerID  string             `json:"followerId" gorm:"type:uuid;index;uniqueIndex:unique_subscription;not null"`
+	Follower    User               `json:"follower" gorm:"foreignKey:FollowerID"`
+	FollowingID string             `json:"followingId" gorm:"type:uuid;index;uniqueIndex:unique_subscription;not null"`
+	Following   User               `json:"following" gorm:"foreignKey:FollowingID"`
+	Status      SubscriptionStatus `json:"status" gorm:"not null;default:'accepted';index"`
+	CreatedAt   time.Time          `json:"createdAt"`
 }
 
 func (s *Subscription) BeforeCreate(tx *gorm.DB) error {
@@ -21,5 +29,8 @@ func (s *Subscription) BeforeCreate(tx *gorm.DB) error {
 		s.ID = uuid.New().String()
 	}
 	s.CreatedAt = time.Now()
+	if s.Status == "" {
+		s.Status = SubscriptionStatusAccepted
+	}
 	return nil
 }
@@ -7,23 +7,42 @@ import (
 	"gorm.io/gorm"
 )
 
+type ProfileVisibility string
+
+const (
+	ProfileVisibilityPublic    ProfileVisibility = "public"
+	ProfileVisibilityFollowers ProfileVisibility = "followers"
+	ProfileVisibilityPrivate   ProfileVisibility = "private"
+)
+
+func (v ProfileVisibility) IsValid() bool {
+	return v == ProfileVisibilityPublic || v == ProfileVisibilityFollowers || v == ProfileVisibilityPrivate
+}
+
 type User struct {
-	ID             string    `json:"id" gorm:"type:uuid;primary_key"`
-	SteamID        string    `json:"steamId" gorm:"unique"`
-	DisplayName    string    `json:"displayName"`
-	AvatarURL      string    `json:"avatarUrl"`
-	ProfileURL     string    `json:"profileUrl"`
-	DiscordTag     string    `json:"discordTag" gorm:"default:null"`
-	CreatedAt      time.Time `json:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt"`
-	LastLoginAt    time.Time `json:"lastLoginAt"`
-	ShowWelcome    bool      `json:"showWelcome" gorm:"default:true"`
-	FollowersCount int       `json:"followersCount" gorm:"-"`
-	FollowingCount int       `json:"followingCount" gorm:"-"`
-	GamesCount     int       `json:"gamesCount" gorm:"-"`
-	TotalPlaytime  int       `json:"totalPlaytime" gorm:"-"`
-	AverageRating  float64   `json:"averageRating" gorm:"-"`
-	IsFollowing    bool      `json:"isFollowing,omitempty" gorm:"-"`
+	ID                string            `json:"id" gorm:"type:uuid;primary_key"`
+	SteamID           string            `json:"steamId" gorm:"unique"`
+	DisplayName       string            `json:"displayName"`
+	AvatarURL         string            `json:"avatarUrl"`
+	ProfileURL        string            `json:"profileUrl"`
+	DiscordTag        string            `json:"discordTag" gorm:"default:null"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	UpdatedAt         time.Time         `json:"updatedAt"`
+	LastLoginAt       time.Time         `json:"lastLoginAt"`
+	ShowWelcome       bool              `json:"showWelcome" gorm:"default:true"`
+	IsAdmin           bool              `json:"isAdmin" gorm:"default:false"`
+	ProfileVisibility ProfileVisibility `json:"profileVisibility" gorm:"not null;default:'public'"`
+	FollowersCount    int               `json:"followersCount" gorm:"-"`
+	FollowingCount    int               `json:"followingCount" gorm:"-"`
+	GamesCount        int               `json:"gamesCount" gorm:"-"`
+	TotalPlaytime     int               `json:"totalPlaytime" gorm:"-"`
+	AverageRating     float64           `json:"averageRating" gorm:"-"`
+	HelpfulScore      int               `json:"helpfulScore" gorm:"-"`
+	IsFollowing       bool              `json:"isFollowing,omitempty" gorm:"-"`
+	IsBlocked         bool              `json:"isBlocked,omitempty" gorm:"-"`
+	IsMuted           bool              `json:"isMuted,omitempty" gorm:"-"`
+	FollowRequested   bool              `json:"followRequested,omitempty" gorm:"-"`
+	CanViewContent    bool              `json:"canViewContent,omitempty" gorm:"-"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) error {
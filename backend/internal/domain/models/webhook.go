@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WebhookFormat string
+
+const (
+	WebhookFormatJSON    WebhookFormat = "json"
+	WebhookFormatDiscord WebhookFormat = "discord"
+)
+
+func (f WebhookFormat) IsValid() bool {
+	return f == WebhookFormatJSON || f == WebhookFormatDiscord
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"
+)
+
+const WebhookEventPing = "ping"
+
+var WebhookEvents = []ActivityType{
+	ActivityTypeAddGame,
+	ActivityTypeUpdateStatus,
+	ActivityTypeRateGame,
+	ActivityTypeFollow,
+}
+
+func IsWebhookEvent(event string) bool {
+	for _, known := range WebhookEvents {
+		if event == string(known) {
+			return true
+		}
+	}
+	return false
+}
+
+type Webhook struct {
+	ID        string        `json:"id" gorm:"type:uuid;primary_key"`
+	UserID    string        `json:"userId" gorm:"type:uuid;not null;index"`
+	User      User          `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	URL       string        `json:"url" gorm:"not null"`
+	Secret    string        `json:"-" gorm:"not null"`
+	Events    []string      `json:"events" gorm:"type:jsonb;serializer:json;not null"`
+	Format    WebhookFormat `json:"format" gorm:"not null"`
+	Active    bool          `json:"active" gorm:"not null"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+func (w *Webhook) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = uuid.New().String()
+	}
+	now := time.Now()
+	w.CreatedAt = now
+	w.UpdatedAt = now
+	return nil
+}
+
+func (w *Webhook) BeforeUpdate(tx *gorm.DB) error {
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+type WebhookDelivery struct {
+	ID             string                `json:"id" gorm:"type:uuid;primary_key"`
+	WebhookID      string                `json:"webhookId" gorm:"type:uuid;not null;index:idx_webhook_delivery_webhook_created,priority:1"`
+	Webhook        Webhook               `json:"-" gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE"`
+	ActivityID     *string               `json:"activityId,omitempty" gorm:"type:uuid;index;default:null"`
+	Activity       *Activity             `json:"-" gorm:"foreignKey:ActivityID;constraint:OnDelete:SET NULL"`
+	Event          string                `json:"event" gorm:"not null"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"not null;index:idx_webhook_delivery_status_next,priority:1"`
+	Attempts       int                   `json:"attempts" gorm:"not null"`
+	NextAttemptAt  time.Time             `json:"nextAttemptAt" gorm:"index:idx_webhook_delivery_status_next,priority:2"`
+	Payload        string                `json:"payload,omitempty" gorm:"type:text"`
+	ResponseStatus *int                  `json:"responseStatus,omitempty" gorm:"default:null"`
+	LastError      string                `json:"lastError,omitempty" gorm:"type:text"`
+	DeliveredAt    *time.Time            `json:"deliveredAt,omitempty" gorm:"default:null"`
+	CreatedAt      time.Time             `json:"createdAt" gorm:"index:idx_webhook_delivery_webhook_created,priority:2"`
+}
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	now := time.Now()
+	d.CreatedAt = now
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = now
+	}
+	return nil
+}
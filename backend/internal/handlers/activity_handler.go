@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
+	"gamecheck/internal/infra/db/repositories"
 	"gamecheck/internal/middleware"
 	"gamecheck/internal/services"
+	"gamecheck/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,40 +15,53 @@ import (
 type ActivityHandler struct {
 	activityService *services.ActivityService
 	authService     *services.AuthService
+	userService     *services.UserService
 }
 
 func NewActivityHandler(
 	activityService *services.ActivityService,
 	authService *services.AuthService,
+	userService *services.UserService,
 ) *ActivityHandler {
 	return &ActivityHandler{
 		activityService: activityService,
 		authService:     authService,
+		userService:     userService,
 	}
 }
 
 func (h *ActivityHandler) RegisterRoutes(router *gin.RouterGroup) {
 	activity := router.Group("/activity")
 	{
-		activity.GET("/all", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetAllActivities)
+		activity.GET("/all", middleware.OptionalAuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetAllActivities)
 		activity.GET("/feed", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetFeed)
-		activity.GET("/user/:userId", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetUserActivity)
+		activity.GET("/user/:userId", middleware.OptionalAuthMiddleware(h.authService), middleware.ProfileAccessMiddleware(h.userService, "userId"), middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetUserActivity)
+		activity.GET("/:id", middleware.OptionalAuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetActivity)
+		activity.POST("/:id/like", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.Like)
+		activity.DELETE("/:id/like", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("deleteLimiter"), h.Unlike)
+		activity.GET("/:id/comments", middleware.OptionalAuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetComments)
+		activity.POST("/:id/comments", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.AddComment)
+		activity.PATCH("/comments/:commentId", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.EditComment)
+		activity.DELETE("/comments/:commentId", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("deleteLimiter"), h.DeleteComment)
 	}
 }
 
 func (h *ActivityHandler) GetAllActivities(ctx *gin.Context) {
-	limit, offset := getPagination(ctx)
-	activities, err := h.activityService.GetAllActivities(limit, offset)
+	cursor, cursorMode, err := getCursor(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch activities"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
 		return
 	}
 
-	if activities == nil {
-		activities = []*services.ActivityResponse{}
+	viewerID, _ := middleware.GetUserID(ctx)
+	limit, offset := getPagination(ctx)
+	page, err := h.activityService.GetAllActivities(viewerID, cursor, limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch activities"})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, activities)
+	respondActivityPage(ctx, page, cursorMode)
 }
 
 func (h *ActivityHandler) GetFeed(ctx *gin.Context) {
@@ -55,18 +71,21 @@ func (h *ActivityHandler) GetFeed(ctx *gin.Context) {
 		return
 	}
 
-	limit, offset := getPagination(ctx)
-	activities, err := h.activityService.GetFeed(userID, limit, offset)
+	cursor, cursorMode, err := getCursor(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch feed"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
 		return
 	}
 
-	if activities == nil {
-		activities = []*services.ActivityResponse{}
+	limit, offset := getPagination(ctx)
+	aggregate := ctx.Query("aggregate") != "false"
+	page, err := h.activityService.GetFeed(userID, cursor, limit, offset, aggregate)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch feed"})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, activities)
+	respondActivityPage(ctx, page, cursorMode)
 }
 
 func (h *ActivityHandler) GetUserActivity(ctx *gin.Context) {
@@ -76,18 +95,192 @@ func (h *ActivityHandler) GetUserActivity(ctx *gin.Context) {
 		return
 	}
 
+	cursor, cursorMode, err := getCursor(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	viewerID, _ := middleware.GetUserID(ctx)
 	limit, offset := getPagination(ctx)
-	activities, err := h.activityService.GetUserActivity(userID, limit, offset)
+	page, err := h.activityService.GetUserActivity(userID, viewerID, cursor, limit, offset)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch activity"})
 		return
 	}
 
-	if activities == nil {
-		activities = []*services.ActivityResponse{}
+	respondActivityPage(ctx, page, cursorMode)
+}
+
+func (h *ActivityHandler) GetActivity(ctx *gin.Context) {
+	viewerID, _ := middleware.GetUserID(ctx)
+	activity, err := h.activityService.GetActivity(ctx.Param("id"), viewerID)
+	if err != nil {
+		respondActivityError(ctx, err, "failed to fetch activity")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, activity)
+}
+
+func (h *ActivityHandler) Like(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	result, err := h.activityService.Like(ctx.Param("id"), userID)
+	if err != nil {
+		respondActivityError(ctx, err, "failed to like activity")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (h *ActivityHandler) Unlike(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	result, err := h.activityService.Unlike(ctx.Param("id"), userID)
+	if err != nil {
+		respondActivityError(ctx, err, "failed to unlike activity")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (h *ActivityHandler) GetComments(ctx *gin.Context) {
+	viewerID, _ := middleware.GetUserID(ctx)
+	limit, offset := getPagination(ctx)
+
+	page, err := h.activityService.GetComments(ctx.Param("id"), viewerID, limit, offset)
+	if err != nil {
+		respondActivityError(ctx, err, "failed to fetch comments")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+func (h *ActivityHandler) AddComment(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, activities)
+	var req struct {
+		Body     string  `json:"body" binding:"required"`
+		ParentID *string `json:"parentId"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := utils.ValidateComment(req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.ParentID != nil && *req.ParentID == "" {
+		req.ParentID = nil
+	}
+
+	comment, err := h.activityService.AddComment(ctx.Param("id"), userID, req.Body, req.ParentID)
+	if err != nil {
+		respondActivityError(ctx, err, "failed to add comment")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, comment)
+}
+
+func (h *ActivityHandler) EditComment(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req struct {
+		Body string `json:"body" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := utils.ValidateComment(req.Body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.activityService.EditComment(ctx.Param("commentId"), userID, req.Body)
+	if err != nil {
+		respondActivityError(ctx, err, "failed to edit comment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+func (h *ActivityHandler) DeleteComment(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.activityService.DeleteComment(ctx.Param("commentId"), userID); err != nil {
+		respondActivityError(ctx, err, "failed to delete comment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "comment deleted"})
+}
+
+func respondActivityError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrActivityNotFound), errors.Is(err, services.ErrCommentNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommentForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidParentComment):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func respondActivityPage(ctx *gin.Context, page *services.ActivityPageResponse, cursorMode bool) {
+	if page.NextCursor != nil {
+		ctx.Header("X-Next-Cursor", *page.NextCursor)
+	}
+	if cursorMode {
+		ctx.JSON(http.StatusOK, page)
+		return
+	}
+	ctx.JSON(http.StatusOK, page.Data)
+}
+
+func getCursor(ctx *gin.Context) (*repositories.Cursor, bool, error) {
+	value, cursorMode := ctx.GetQuery("cursor")
+	if value == "" {
+		return nil, cursorMode, nil
+	}
+
+	cursor, err := repositories.DecodeCursor(value)
+	if err != nil {
+		return nil, true, err
+	}
+	return cursor, true, nil
 }
 
 func getPagination(ctx *gin.Context) (int, int) {
@@ -10,12 +10,20 @@ import (
 )
 
 type Handlers struct {
-	Auth         *AuthHandler
-	User         *UserHandler
-	Progress     *ProgressHandler
-	Activity     *ActivityHandler
-	Library      *LibraryHandler
-	Subscription *SubscriptionHandler
+	Auth           *AuthHandler
+	User           *UserHandler
+	Progress       *ProgressHandler
+	Activity       *ActivityHandler
+	Library        *LibraryHandler
+	Subscription   *SubscriptionHandler
+	Review         *ReviewHandler
+	Notification   *NotificationHandler
+	Realtime       *RealtimeHandler
+	Syndication    *SyndicationHandler
+	Webhook        *WebhookHandler
+	Recommendation *RecommendationHandler
+	Search         *SearchHandler
+	Series         *SeriesHandler
 }
 
 func New(
@@ -33,24 +41,67 @@ func New(
 		User: NewUserHandler(
 			svcs.User,
 			svcs.Auth,
+			svcs.Compatibility,
 		),
 		Progress: NewProgressHandler(
 			svcs.Progress,
 			svcs.Auth,
 			svcs.Steam,
+			svcs.User,
+			svcs.Library,
+			svcs.ProgressImport,
 		),
 		Activity: NewActivityHandler(
 			svcs.Activity,
 			svcs.Auth,
+			svcs.User,
 		),
 		Library: NewLibraryHandler(
 			svcs.Library,
+			svcs.Auth,
+			svcs.SimilarGame,
+			svcs.Chart,
 		),
 		Subscription: NewSubscriptionHandler(
 			repos.Subscription,
 			svcs.Activity,
+			svcs.Notification,
+			svcs.Auth,
+			svcs.User,
+		),
+		Review: NewReviewHandler(
+			svcs.Review,
+			svcs.Auth,
+		),
+		Notification: NewNotificationHandler(
+			svcs.Notification,
+			svcs.Auth,
+		),
+		Realtime: NewRealtimeHandler(
+			svcs.Realtime,
 			svcs.Auth,
 		),
+		Syndication: NewSyndicationHandler(
+			cfg,
+			svcs.Syndication,
+		),
+		Webhook: NewWebhookHandler(
+			svcs.Webhook,
+			svcs.Auth,
+		),
+		Recommendation: NewRecommendationHandler(
+			svcs.Recommendation,
+			svcs.Auth,
+		),
+		Search: NewSearchHandler(
+			svcs.Search,
+			svcs.Auth,
+		),
+		Series: NewSeriesHandler(
+			svcs.Series,
+			svcs.Auth,
+			svcs.User,
+		),
 	}
 }
 
@@ -61,6 +112,14 @@ func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
 	h.Activity.RegisterRoutes(router)
 	h.Library.RegisterRoutes(router)
 	h.Subscription.RegisterRoutes(router)
+	h.Review.RegisterRoutes(router)
+	h.Notification.RegisterRoutes(router)
+	h.Realtime.RegisterRoutes(router)
+	h.Syndication.RegisterRoutes(router)
+	h.Webhook.RegisterRoutes(router)
+	h.Recommendation.RegisterRoutes(router)
+	h.Search.RegisterRoutes(router)
+	h.Series.RegisterRoutes(router)
 
 	router.GET("/health", HealthHandler)
 }
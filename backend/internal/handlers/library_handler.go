@@ -7,41 +7,72 @@ import (
 	"strings"
 	"unicode/utf8"
 
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
 	"gamecheck/internal/middleware"
 	"gamecheck/internal/services"
+	"gamecheck/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 type LibraryHandler struct {
-	libraryService *services.LibraryService
+	libraryService     *services.LibraryService
+	authService        *services.AuthService
+	similarGameService *services.SimilarGameService
+	chartService       *services.ChartService
 }
 
-func NewLibraryHandler(libraryService *services.LibraryService) *LibraryHandler {
+func NewLibraryHandler(
+	libraryService *services.LibraryService,
+	authService *services.AuthService,
+	similarGameService *services.SimilarGameService,
+	chartService *services.ChartService,
+) *LibraryHandler {
 	return &LibraryHandler{
-		libraryService: libraryService,
+		libraryService:     libraryService,
+		authService:        authService,
+		similarGameService: similarGameService,
+		chartService:       chartService,
 	}
 }
 
 func (h *LibraryHandler) RegisterRoutes(router *gin.RouterGroup) {
 	library := router.Group("/library")
 	{
+		library.POST("/submissions", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.SubmitGame)
 		library.GET("", h.ListGames)
+		library.GET("/charts/:chart", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetChart)
 		library.GET("/suggest", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.SuggestGames)
-		library.GET("/app/:appId", h.GetGameByAppID)
-		library.GET("/:id", h.GetGame)
+		library.GET("/app/:appId", middleware.OptionalAuthMiddleware(h.authService), h.GetGameByAppID)
+		library.GET("/:id", middleware.OptionalAuthMiddleware(h.authService), h.GetGame)
+		library.GET("/:id/similar", middleware.OptionalAuthMiddleware(h.authService), h.GetSimilarGames)
+		library.GET("/:id/stats", middleware.OptionalAuthMiddleware(h.authService), h.GetGameStats)
+	}
+
+	moderation := router.Group("/moderation/games")
+	moderation.Use(middleware.AuthMiddleware(h.authService), middleware.AdminMiddleware(h.authService))
+	{
+		moderation.GET("", h.ListSubmissions)
+		moderation.GET("/duplicates", h.ListDuplicates)
+		moderation.PATCH("/:id", h.ModerateSubmission)
+		moderation.POST("/:id/steam", h.AttachSteamApp)
+		moderation.POST("/:id/merge", h.MergeGames)
 	}
 }
 
 func (h *LibraryHandler) ListGames(ctx *gin.Context) {
 	var req struct {
-		Limit  int    `form:"limit,default=12"`
-		Offset int    `form:"offset,default=0"`
-		Sort   string `form:"sort,default=createdAt"`
-		Order  string `form:"order,default=desc"`
-		Search string `form:"search"`
-		Genre  string `form:"genre"`
+		Limit      int     `form:"limit,default=12"`
+		Offset     int     `form:"offset,default=0"`
+		Sort       string  `form:"sort,default=createdAt"`
+		Order      string  `form:"order,default=desc"`
+		Search     string  `form:"search"`
+		Genre      string  `form:"genre"`
+		Match      string  `form:"match,default=all"`
+		MinRating  float64 `form:"minRating"`
+		MinRatings int     `form:"minRatings"`
 	}
 
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -49,6 +80,19 @@ func (h *LibraryHandler) ListGames(ctx *gin.Context) {
 		return
 	}
 
+	if req.Match != "all" && req.Match != "any" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "match must be all or any"})
+		return
+	}
+	if req.MinRating < 0 || req.MinRating > 10 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "minRating must be between 0 and 10"})
+		return
+	}
+	if req.MinRatings < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "minRatings must not be negative"})
+		return
+	}
+
 	if req.Limit > 50 {
 		req.Limit = 50
 	}
@@ -59,20 +103,51 @@ func (h *LibraryHandler) ListGames(ctx *gin.Context) {
 		req.Offset = 0
 	}
 
-	games, total, err := h.libraryService.ListGames(req.Limit, req.Offset, req.Search, req.Genre, req.Sort, req.Order)
+	filter := repositories.LibraryFilter{
+		Search:     req.Search,
+		Genre:      req.Genre,
+		Genres:     queryList(ctx, "genres"),
+		Categories: queryList(ctx, "categories"),
+		Tags:       queryList(ctx, "tags"),
+		MatchAny:   req.Match == "any",
+		MinRating:  req.MinRating,
+		MinRatings: req.MinRatings,
+	}
+
+	games, total, err := h.libraryService.ListGames(filter, req.Limit, req.Offset, req.Sort, req.Order)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch library games"})
 		return
 	}
 
+	facets, err := h.libraryService.GetFacets(filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch library facets"})
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{
 		"data":   games,
 		"total":  total,
 		"limit":  req.Limit,
 		"offset": req.Offset,
+		"facets": facets,
 	})
 }
 
+func queryList(ctx *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range ctx.QueryArray(key) {
+		for _, value := range strings.Split(raw, ",") {
+			value = strings.TrimSpace(value)
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
 func (h *LibraryHandler) GetGame(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -81,8 +156,9 @@ func (h *LibraryHandler) GetGame(ctx *gin.Context) {
 	}
 
 	var req struct {
-		Limit  int `form:"limit,default=10"`
-		Offset int `form:"offset,default=0"`
+		Limit  int    `form:"limit,default=10"`
+		Offset int    `form:"offset,default=0"`
+		Sort   string `form:"sort,default=createdAt"`
 	}
 	_ = ctx.ShouldBindQuery(&req)
 	if req.Limit < 1 {
@@ -92,7 +168,8 @@ func (h *LibraryHandler) GetGame(ctx *gin.Context) {
 		req.Limit = 10
 	}
 
-	game, err := h.libraryService.GetGameByID(id, req.Limit, req.Offset)
+	viewerID, _ := middleware.GetUserID(ctx)
+	game, err := h.libraryService.GetGameByID(id, viewerID, req.Sort, req.Limit, req.Offset)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": "library game not found"})
@@ -105,6 +182,75 @@ func (h *LibraryHandler) GetGame(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, game)
 }
 
+func (h *LibraryHandler) GetGameStats(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+
+	viewerID, _ := middleware.GetUserID(ctx)
+	stats, err := h.libraryService.GetGameStats(id, viewerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "library game not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch game stats"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
+
+func (h *LibraryHandler) GetSimilarGames(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid game id"})
+		return
+	}
+
+	viewerID, _ := middleware.GetUserID(ctx)
+	limit, offset := getPagination(ctx)
+	games, total, err := h.similarGameService.GetSimilarGames(id, viewerID, limit, offset)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "library game not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch similar games"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   games,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *LibraryHandler) GetChart(ctx *gin.Context) {
+	limit, offset := getPagination(ctx)
+	chart := models.ChartType(ctx.Param("chart"))
+	window := models.ChartWindow(ctx.Query("window"))
+
+	page, err := h.chartService.GetChart(chart, window, strings.TrimSpace(ctx.Query("genre")), limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidChart):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "chart not found"})
+		case errors.Is(err, services.ErrInvalidChartWindow):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid chart window"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch chart"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
 func (h *LibraryHandler) GetGameByAppID(ctx *gin.Context) {
 	appIDParam := ctx.Param("appId")
 	if appIDParam == "" {
@@ -119,8 +265,9 @@ func (h *LibraryHandler) GetGameByAppID(ctx *gin.Context) {
 	}
 
 	var req struct {
-		Limit  int `form:"limit,default=10"`
-		Offset int `form:"offset,default=0"`
+		Limit  int    `form:"limit,default=10"`
+		Offset int    `form:"offset,default=0"`
+		Sort   string `form:"sort,default=createdAt"`
 	}
 	_ = ctx.ShouldBindQuery(&req)
 	if req.Limit < 1 {
@@ -130,7 +277,8 @@ func (h *LibraryHandler) GetGameByAppID(ctx *gin.Context) {
 		req.Limit = 10
 	}
 
-	game, err := h.libraryService.GetGameByAppID(appID, req.Limit, req.Offset)
+	viewerID, _ := middleware.GetUserID(ctx)
+	game, err := h.libraryService.GetGameByAppID(appID, viewerID, req.Sort, req.Limit, req.Offset)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": "library game not found"})
@@ -181,3 +329,177 @@ func (h *LibraryHandler) SuggestGames(ctx *gin.Context) {
 		"items":  items,
 	})
 }
+
+func (h *LibraryHandler) SubmitGame(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req struct {
+		Name        string `json:"name" binding:"required"`
+		Platform    string `json:"platform"`
+		ReleaseYear *int   `json:"releaseYear"`
+		CoverURL    string `json:"coverUrl"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	name := strings.ReplaceAll(strings.TrimSpace(req.Name), "’", "'")
+	if err := utils.ValidateGameName(name); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.Platform)) > 50 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "platform must not exceed 50 characters"})
+		return
+	}
+
+	game, err := h.libraryService.SubmitGame(userID, services.LibrarySubmission{
+		Name:        name,
+		Platform:    req.Platform,
+		ReleaseYear: req.ReleaseYear,
+		CoverURL:    req.CoverURL,
+	})
+	if err != nil {
+		respondLibraryGameError(ctx, err, "failed to submit game")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, game)
+}
+
+func (h *LibraryHandler) ListSubmissions(ctx *gin.Context) {
+	var req struct {
+		Limit  int    `form:"limit,default=20"`
+		Offset int    `form:"offset,default=0"`
+		Status string `form:"status,default=pending"`
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	if req.Limit > 50 {
+		req.Limit = 50
+	}
+	if req.Limit < 1 {
+		req.Limit = 20
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if strings.EqualFold(req.Status, "all") {
+		req.Status = ""
+	}
+
+	games, total, err := h.libraryService.ListSubmissions(models.LibraryGameModerationStatus(req.Status), req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch submissions"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   games,
+		"total":  total,
+		"limit":  req.Limit,
+		"offset": req.Offset,
+	})
+}
+
+func (h *LibraryHandler) ModerateSubmission(ctx *gin.Context) {
+	var req struct {
+		Action string `json:"action" binding:"required"`
+		Note   string `json:"note"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.libraryService.ModerateSubmission(ctx.Param("id"), req.Action, req.Note); err != nil {
+		respondLibraryGameError(ctx, err, "failed to moderate submission")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "submission moderated"})
+}
+
+func (h *LibraryHandler) AttachSteamApp(ctx *gin.Context) {
+	var req struct {
+		SteamAppID int `json:"steamAppId" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.SteamAppID <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid steam app id"})
+		return
+	}
+
+	game, err := h.libraryService.AttachSteamApp(ctx.Param("id"), req.SteamAppID)
+	if err != nil {
+		respondLibraryGameError(ctx, err, "failed to attach steam app")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, game)
+}
+
+func (h *LibraryHandler) ListDuplicates(ctx *gin.Context) {
+	limit, offset := getPagination(ctx)
+	groups, total, err := h.libraryService.ListDuplicateGroups(limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch duplicate games"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   groups,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *LibraryHandler) MergeGames(ctx *gin.Context) {
+	var req struct {
+		SourceIDs []string `json:"sourceIds" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	game, err := h.libraryService.MergeGames(ctx.Param("id"), req.SourceIDs)
+	if err != nil {
+		respondLibraryGameError(ctx, err, "failed to merge games")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, game)
+}
+
+func respondLibraryGameError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "library game not found"})
+	case errors.Is(err, services.ErrLibraryGameUnavailable):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrLibraryGameExists),
+		errors.Is(err, services.ErrSteamAppAlreadyAttached),
+		errors.Is(err, services.ErrSteamAppAlreadyInLibrary):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidLibraryModeration),
+		errors.Is(err, services.ErrInvalidLibraryMerge),
+		errors.Is(err, services.ErrInvalidLibraryReleaseYear),
+		errors.Is(err, services.ErrInvalidLibraryCoverURL),
+		errors.Is(err, services.ErrInvalidProgressVisibility),
+		errors.Is(err, services.ErrInvalidPlatform),
+		errors.Is(err, services.ErrInvalidOwnership),
+		errors.Is(err, services.ErrInvalidStore):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"gamecheck/internal/middleware"
+	"gamecheck/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+	authService         *services.AuthService
+}
+
+func NewNotificationHandler(
+	notificationService *services.NotificationService,
+	authService *services.AuthService,
+) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		authService:         authService,
+	}
+}
+
+func (h *NotificationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	notifications := router.Group("/notifications")
+	notifications.Use(middleware.AuthMiddleware(h.authService))
+	{
+		notifications.GET("", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.List)
+		notifications.GET("/unread-count", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.UnreadCount)
+		notifications.POST("/read-all", h.MarkAllRead)
+		notifications.POST("/:id/read", h.MarkRead)
+		notifications.GET("/preferences", h.GetPreferences)
+		notifications.PUT("/preferences", middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.UpdatePreferences)
+	}
+}
+
+func (h *NotificationHandler) List(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req struct {
+		Unread bool `form:"unread"`
+	}
+	_ = ctx.ShouldBindQuery(&req)
+
+	limit, offset := getPagination(ctx)
+	page, err := h.notificationService.List(userID, req.Unread, limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch notifications"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+func (h *NotificationHandler) UnreadCount(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	count, err := h.notificationService.UnreadCount(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count notifications"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"unreadCount": count})
+}
+
+func (h *NotificationHandler) MarkRead(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.notificationService.MarkRead(ctx.Param("id"), userID); err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark notification as read"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "notification marked as read"})
+}
+
+func (h *NotificationHandler) MarkAllRead(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.notificationService.MarkAllRead(userID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark notifications as read"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "notifications marked as read"})
+}
+
+func (h *NotificationHandler) GetPreferences(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	prefs, err := h.notificationService.GetPreferences(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch preferences"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+func (h *NotificationHandler) UpdatePreferences(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req struct {
+		Preferences map[string]bool `json:"preferences" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	prefs, err := h.notificationService.UpdatePreferences(userID, req.Preferences)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidNotificationType) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update preferences"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
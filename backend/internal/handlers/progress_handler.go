@@ -1,32 +1,49 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"log"
 	"net/http"
 	"strings"
 
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
 	"gamecheck/internal/middleware"
 	"gamecheck/internal/services"
 	"gamecheck/pkg/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+const progressImportMaxBytes = 5 << 20
+
 type ProgressHandler struct {
 	progressService *services.ProgressService
 	authService     *services.AuthService
 	steamService    *services.SteamService
+	userService     *services.UserService
+	libraryService  *services.LibraryService
+	importService   *services.ProgressImportService
 }
 
 func NewProgressHandler(
 	progressService *services.ProgressService,
 	authService *services.AuthService,
 	steamService *services.SteamService,
+	userService *services.UserService,
+	libraryService *services.LibraryService,
+	importService *services.ProgressImportService,
 ) *ProgressHandler {
 	return &ProgressHandler{
 		progressService: progressService,
 		authService:     authService,
 		steamService:    steamService,
+		userService:     userService,
+		libraryService:  libraryService,
+		importService:   importService,
 	}
 }
 
@@ -34,7 +51,11 @@ func (h *ProgressHandler) RegisterRoutes(router *gin.RouterGroup) {
 	progress := router.Group("/progress")
 	{
 		progress.GET("", middleware.AuthMiddleware(h.authService), h.GetUserGames)
-		progress.GET("/user/:userId", h.GetUserGamesByID)
+		progress.GET("/platforms", h.GetPlatforms)
+		progress.GET("/export", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.ExportGames)
+		progress.POST("/import", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.ImportGames)
+		progress.GET("/import/:jobId", middleware.AuthMiddleware(h.authService), h.GetImportJob)
+		progress.GET("/user/:userId", middleware.OptionalAuthMiddleware(h.authService), middleware.ProfileAccessMiddleware(h.userService, "userId"), h.GetUserGamesByID)
 		progress.POST("", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.AddGame)
 		progress.PATCH("/:id", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.UpdateGame)
 		progress.DELETE("/:id", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("deleteLimiter"), h.DeleteGame)
@@ -50,7 +71,18 @@ func (h *ProgressHandler) GetUserGames(ctx *gin.Context) {
 	}
 
 	req := getProgressListQuery(ctx)
-	page, err := h.progressService.GetUserGamesPage(userID, req.Status, req.Limit, req.Offset, req.Summary)
+	cursor, _, err := getCursor(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	if err := h.progressService.ValidateFilter(req.filter()); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.progressService.GetUserGamesPage(userID, userID, req.filter(), cursor, req.Limit, req.Offset, req.Summary)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch games"})
 		return
@@ -69,9 +101,21 @@ func (h *ProgressHandler) GetUserGamesByID(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
 		return
 	}
+	viewerID, _ := middleware.GetUserID(ctx)
 
 	req := getProgressListQuery(ctx)
-	page, err := h.progressService.GetUserGamesPage(userID, req.Status, req.Limit, req.Offset, req.Summary)
+	cursor, _, err := getCursor(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	if err := h.progressService.ValidateFilter(req.filter()); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.progressService.GetUserGamesPage(userID, viewerID, req.filter(), cursor, req.Limit, req.Offset, req.Summary)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch games"})
 		return
@@ -92,11 +136,16 @@ func (h *ProgressHandler) AddGame(ctx *gin.Context) {
 	}
 
 	var req struct {
-		Name       string `json:"name" binding:"required"`
-		Status     string `json:"status" binding:"required"`
-		Rating     *int   `json:"rating"`
-		Review     string `json:"review"`
-		SteamAppID *int   `json:"steamAppId"`
+		Name          string  `json:"name"`
+		Status        string  `json:"status" binding:"required"`
+		Rating        *int    `json:"rating"`
+		Review        string  `json:"review"`
+		SteamAppID    *int    `json:"steamAppId"`
+		LibraryGameID *string `json:"libraryGameId"`
+		Visibility    string  `json:"visibility"`
+		Platform      string  `json:"platform"`
+		Ownership     string  `json:"ownership"`
+		Store         string  `json:"store"`
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -106,11 +155,18 @@ func (h *ProgressHandler) AddGame(ctx *gin.Context) {
 
 	normalizedName := strings.ReplaceAll(strings.TrimSpace(req.Name), "’", "'")
 
-	if err := utils.ValidateGameName(normalizedName); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if req.LibraryGameID != nil && strings.TrimSpace(*req.LibraryGameID) == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid library game id"})
 		return
 	}
 
+	if req.LibraryGameID == nil {
+		if err := utils.ValidateGameName(normalizedName); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	if req.Rating != nil {
 		if err := utils.ValidateRating(*req.Rating); err != nil {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -123,10 +179,24 @@ func (h *ProgressHandler) AddGame(ctx *gin.Context) {
 		return
 	}
 
+	visibility := models.ProfileVisibility(req.Visibility)
+	if visibility != "" && !visibility.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": services.ErrInvalidProgressVisibility.Error()})
+		return
+	}
+
 	var steamAppID *int
 	var playtimeForever *int
 
-	if req.SteamAppID != nil {
+	if req.LibraryGameID != nil {
+		game, err := h.libraryService.GetGameForProgress(*req.LibraryGameID, userID)
+		if err != nil {
+			respondLibraryGameError(ctx, err, "failed to add game")
+			return
+		}
+		steamAppID = game.SteamAppID
+		normalizedName = game.Name
+	} else if req.SteamAppID != nil {
 		if *req.SteamAppID <= 0 {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid steam app id"})
 			return
@@ -156,7 +226,7 @@ func (h *ProgressHandler) AddGame(ctx *gin.Context) {
 		}
 	}
 
-	exists, err := h.progressService.ExistsForUser(userID, steamAppID, normalizedName)
+	exists, err := h.progressService.ExistsForUser(userID, steamAppID, req.LibraryGameID, normalizedName)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check existing games"})
 		return
@@ -173,10 +243,17 @@ func (h *ProgressHandler) AddGame(ctx *gin.Context) {
 		req.Rating,
 		req.Review,
 		steamAppID,
+		req.LibraryGameID,
 		playtimeForever,
+		visibility,
+		services.ProgressOwnership{
+			Platform:  strings.TrimSpace(req.Platform),
+			Ownership: strings.TrimSpace(req.Ownership),
+			Store:     strings.TrimSpace(req.Store),
+		},
 	)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add game"})
+		respondLibraryGameError(ctx, err, "failed to add game")
 		return
 	}
 
@@ -209,7 +286,12 @@ func (h *ProgressHandler) UpdateGame(ctx *gin.Context) {
 		Rating               *int    `json:"rating"`
 		Review               *string `json:"review"`
 		SteamAppID           *int    `json:"steamAppId"`
+		LibraryGameID        *string `json:"libraryGameId"`
 		SteamPlaytimeForever *int    `json:"steamPlaytimeForever"`
+		Visibility           *string `json:"visibility"`
+		Platform             *string `json:"platform"`
+		Ownership            *string `json:"ownership"`
+		Store                *string `json:"store"`
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -238,6 +320,11 @@ func (h *ProgressHandler) UpdateGame(ctx *gin.Context) {
 		}
 	}
 
+	if req.Visibility != nil && !models.ProfileVisibility(*req.Visibility).IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": services.ErrInvalidProgressVisibility.Error()})
+		return
+	}
+
 	if req.SteamAppID != nil {
 		user, err := h.authService.GetUserByID(userID)
 		if err == nil && user != nil && user.SteamID != "" {
@@ -255,16 +342,27 @@ func (h *ProgressHandler) UpdateGame(ctx *gin.Context) {
 		req.Rating,
 		req.Review,
 		req.SteamAppID,
+		req.LibraryGameID,
 		req.SteamPlaytimeForever,
+		req.Visibility,
+		services.ProgressOwnershipUpdate{
+			Platform:  req.Platform,
+			Ownership: req.Ownership,
+			Store:     req.Store,
+		},
 	)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update game"})
+		respondLibraryGameError(ctx, err, "failed to update game")
 		return
 	}
 
 	ctx.JSON(http.StatusOK, updated)
 }
 
+func (h *ProgressHandler) GetPlatforms(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, h.progressService.GetPlatformCatalog())
+}
+
 func (h *ProgressHandler) DeleteGame(ctx *gin.Context) {
 	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
@@ -336,11 +434,155 @@ func (h *ProgressHandler) UpdateSteamData(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, updated)
 }
 
+func (h *ProgressHandler) ExportGames(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	export, err := h.importService.ExportProgress(userID, ctx.Query("format"))
+	if err != nil {
+		respondProgressImportError(ctx, err, "failed to export games")
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="`+export.Filename+`"`)
+	ctx.Data(http.StatusOK, export.ContentType, export.Body)
+}
+
+func (h *ProgressHandler) ImportGames(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, progressImportMaxBytes)
+
+	param := ctx.Query
+	var data []byte
+	var filename string
+	if strings.HasPrefix(ctx.ContentType(), "multipart/form-data") {
+		file, err := ctx.FormFile("file")
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return
+		}
+		opened, err := file.Open()
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid file"})
+			return
+		}
+		defer opened.Close()
+		if data, err = io.ReadAll(opened); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid file"})
+			return
+		}
+		filename = file.Filename
+		param = func(key string) string {
+			if value := ctx.PostForm(key); value != "" {
+				return value
+			}
+			return ctx.Query(key)
+		}
+	} else {
+		if data, err = io.ReadAll(ctx.Request.Body); err != nil {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+			return
+		}
+	}
+
+	var mapping map[string]string
+	if raw := param("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": services.ErrInvalidImportMapping.Error()})
+			return
+		}
+	}
+
+	job, err := h.importService.StartImport(userID, services.ProgressImportRequest{
+		Format:     importFormat(param("format"), filename, ctx.ContentType()),
+		Duplicates: param("duplicates"),
+		Mapping:    mapping,
+		Data:       data,
+	})
+	if err != nil {
+		respondProgressImportError(ctx, err, "failed to import games")
+		return
+	}
+
+	if job.Status == models.ProgressImportCompleted {
+		ctx.JSON(http.StatusOK, job)
+		return
+	}
+	ctx.JSON(http.StatusAccepted, job)
+}
+
+func (h *ProgressHandler) GetImportJob(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	job, err := h.importService.GetJob(ctx.Param("jobId"), userID)
+	if err != nil {
+		respondProgressImportError(ctx, err, "failed to fetch import")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
+
+func importFormat(format, filename, contentType string) string {
+	if format != "" {
+		return format
+	}
+	switch {
+	case strings.HasSuffix(strings.ToLower(filename), ".json"), strings.Contains(contentType, "json"):
+		return "json"
+	case strings.HasSuffix(strings.ToLower(filename), ".csv"), strings.Contains(contentType, "csv"):
+		return "csv"
+	default:
+		return ""
+	}
+}
+
+func respondProgressImportError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "import not found"})
+	case errors.Is(err, services.ErrImportTooLarge):
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidImportFormat),
+		errors.Is(err, services.ErrInvalidImportMapping),
+		errors.Is(err, services.ErrInvalidImportDuplicates),
+		errors.Is(err, services.ErrInvalidImportFile),
+		errors.Is(err, services.ErrEmptyImport):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
 type progressListQuery struct {
-	Limit   int    `form:"limit,default=30"`
-	Offset  int    `form:"offset,default=0"`
-	Status  string `form:"status"`
-	Summary bool   `form:"summary"`
+	Limit     int    `form:"limit,default=30"`
+	Offset    int    `form:"offset,default=0"`
+	Status    string `form:"status"`
+	Platform  string `form:"platform"`
+	Ownership string `form:"ownership"`
+	Store     string `form:"store"`
+	Summary   bool   `form:"summary"`
+}
+
+func (q progressListQuery) filter() repositories.ProgressFilter {
+	return repositories.ProgressFilter{
+		Status:    q.Status,
+		Platform:  q.Platform,
+		Ownership: q.Ownership,
+		Store:     q.Store,
+	}
 }
 
 func getProgressListQuery(ctx *gin.Context) progressListQuery {
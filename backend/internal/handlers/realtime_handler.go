@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gamecheck/internal/infra/realtime"
+	"gamecheck/internal/middleware"
+	"gamecheck/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	realtimeHeartbeatInterval = 25 * time.Second
+	realtimeRetryMillis       = 3000
+)
+
+type RealtimeHandler struct {
+	realtimeService *services.RealtimeService
+	authService     *services.AuthService
+}
+
+func NewRealtimeHandler(
+	realtimeService *services.RealtimeService,
+	authService *services.AuthService,
+) *RealtimeHandler {
+	return &RealtimeHandler{
+		realtimeService: realtimeService,
+		authService:     authService,
+	}
+}
+
+func (h *RealtimeHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/realtime/stream", middleware.StreamAuthMiddleware(h.authService), h.Stream)
+}
+
+func (h *RealtimeHandler) Stream(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.Query("lastEventId")
+	}
+
+	client, replay, err := h.realtimeService.Subscribe(userID, lastEventID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to open stream"})
+		return
+	}
+	defer h.realtimeService.Unsubscribe(client)
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	fmt.Fprintf(ctx.Writer, "retry: %d\n\n", realtimeRetryMillis)
+	ctx.Writer.Flush()
+
+	sent := make(map[string]struct{}, len(replay))
+	for _, event := range replay {
+		if err := writeRealtimeEvent(ctx.Writer, event); err != nil {
+			return
+		}
+		sent[event.ID] = struct{}{}
+	}
+
+	heartbeat := time.NewTicker(realtimeHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(ctx.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		case event, ok := <-client.Events():
+			if !ok {
+				return
+			}
+			if _, duplicate := sent[event.ID]; duplicate {
+				continue
+			}
+			if err := writeRealtimeEvent(ctx.Writer, event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeRealtimeEvent(w gin.ResponseWriter, event realtime.Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+		return err
+	}
+	w.Flush()
+	return nil
+}
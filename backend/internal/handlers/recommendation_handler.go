@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gamecheck/internal/middleware"
+	"gamecheck/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationHandler struct {
+	recommendationService *services.RecommendationService
+	authService           *services.AuthService
+}
+
+func NewRecommendationHandler(
+	recommendationService *services.RecommendationService,
+	authService *services.AuthService,
+) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationService: recommendationService,
+		authService:           authService,
+	}
+}
+
+func (h *RecommendationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	recommendations := router.Group("/recommendations")
+	recommendations.Use(middleware.AuthMiddleware(h.authService))
+	{
+		recommendations.GET("", h.GetRecommendations)
+		recommendations.POST("/refresh", middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.Refresh)
+	}
+}
+
+func (h *RecommendationHandler) GetRecommendations(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	limit, offset := getPagination(ctx)
+	page, err := h.recommendationService.GetRecommendations(userID, limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch recommendations"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+func (h *RecommendationHandler) Refresh(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.recommendationService.Recompute(userID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to refresh recommendations"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "recommendations refreshed"})
+}
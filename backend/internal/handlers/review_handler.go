@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/middleware"
+	"gamecheck/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	reviewService *services.ReviewService
+	authService   *services.AuthService
+}
+
+func NewReviewHandler(
+	reviewService *services.ReviewService,
+	authService *services.AuthService,
+) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+		authService:   authService,
+	}
+}
+
+func (h *ReviewHandler) RegisterRoutes(router *gin.RouterGroup) {
+	reviews := router.Group("/library/reviews")
+	reviews.Use(middleware.AuthMiddleware(h.authService))
+	{
+		reviews.POST("/:reviewId/vote", middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.Vote)
+		reviews.DELETE("/:reviewId/vote", middleware.RateLimitByUserOrIPFromContext("deleteLimiter"), h.RemoveVote)
+		reviews.POST("/:reviewId/report", middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.Report)
+	}
+
+	moderation := router.Group("/moderation")
+	moderation.Use(middleware.AuthMiddleware(h.authService), middleware.AdminMiddleware(h.authService))
+	{
+		moderation.GET("/reports", h.ListReports)
+		moderation.PATCH("/reports/:id", h.ResolveReport)
+	}
+}
+
+func (h *ReviewHandler) Vote(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req struct {
+		Helpful *bool `json:"helpful" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	result, err := h.reviewService.Vote(ctx.Param("reviewId"), userID, *req.Helpful)
+	if err != nil {
+		respondReviewError(ctx, err, "failed to vote")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (h *ReviewHandler) RemoveVote(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	result, err := h.reviewService.RemoveVote(ctx.Param("reviewId"), userID)
+	if err != nil {
+		respondReviewError(ctx, err, "failed to remove vote")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (h *ReviewHandler) Report(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req struct {
+		Reason  string `json:"reason" binding:"required"`
+		Details string `json:"details"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if utf8.RuneCountInString(req.Details) > 500 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "details must not exceed 500 characters"})
+		return
+	}
+
+	reason := models.ReviewReportReason(strings.ToLower(strings.TrimSpace(req.Reason)))
+	report, err := h.reviewService.Report(ctx.Param("reviewId"), userID, reason, req.Details)
+	if err != nil {
+		respondReviewError(ctx, err, "failed to report review")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, report)
+}
+
+func (h *ReviewHandler) ListReports(ctx *gin.Context) {
+	var req struct {
+		Limit  int    `form:"limit,default=20"`
+		Offset int    `form:"offset,default=0"`
+		Status string `form:"status,default=pending"`
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	if req.Limit > 50 {
+		req.Limit = 50
+	}
+	if req.Limit < 1 {
+		req.Limit = 20
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if strings.EqualFold(req.Status, "all") {
+		req.Status = ""
+	}
+
+	reports, total, err := h.reviewService.ListReports(req.Status, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch reports"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   reports,
+		"total":  total,
+		"limit":  req.Limit,
+		"offset": req.Offset,
+	})
+}
+
+func (h *ReviewHandler) ResolveReport(ctx *gin.Context) {
+	moderatorID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req struct {
+		Action string `json:"action" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.reviewService.ResolveReport(ctx.Param("id"), moderatorID, req.Action); err != nil {
+		respondReviewError(ctx, err, "failed to resolve report")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "report resolved"})
+}
+
+func respondReviewError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound), errors.Is(err, services.ErrReportNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyReported), errors.Is(err, services.ErrReportAlreadyResolved):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCannotVoteOwnReview),
+		errors.Is(err, services.ErrCannotReportOwnReview),
+		errors.Is(err, services.ErrInvalidReportReason),
+		errors.Is(err, services.ErrInvalidReportAction):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrReviewAuthorBlocked):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"gamecheck/internal/middleware"
+	"gamecheck/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchService *services.SearchService
+	authService   *services.AuthService
+}
+
+func NewSearchHandler(
+	searchService *services.SearchService,
+	authService *services.AuthService,
+) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		authService:   authService,
+	}
+}
+
+func (h *SearchHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/search", middleware.OptionalAuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.Search)
+}
+
+func (h *SearchHandler) Search(ctx *gin.Context) {
+	limit := 5
+	if raw := ctx.Query("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = value
+	}
+	if limit > 20 {
+		limit = 20
+	}
+
+	var scopes []services.SearchScope
+	for _, value := range queryList(ctx, "type") {
+		scope := services.SearchScope(value)
+		switch scope {
+		case services.SearchScopeGames, services.SearchScopeUsers, services.SearchScopeReviews:
+			scopes = append(scopes, scope)
+		default:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "type must be games, users or reviews"})
+			return
+		}
+	}
+
+	viewerID, _ := middleware.GetUserID(ctx)
+	results, err := h.searchService.Search(ctx.Query("q"), viewerID, scopes, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results)
+}
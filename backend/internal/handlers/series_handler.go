@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"gamecheck/internal/middleware"
+	"gamecheck/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SeriesHandler struct {
+	seriesService *services.SeriesService
+	authService   *services.AuthService
+	userService   *services.UserService
+}
+
+func NewSeriesHandler(
+	seriesService *services.SeriesService,
+	authService *services.AuthService,
+	userService *services.UserService,
+) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService: seriesService,
+		authService:   authService,
+		userService:   userService,
+	}
+}
+
+func (h *SeriesHandler) RegisterRoutes(router *gin.RouterGroup) {
+	series := router.Group("/series")
+	{
+		series.GET("", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.ListSeries)
+		series.GET("/:id", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetSeries)
+		series.GET("/:id/progress/:userId", middleware.OptionalAuthMiddleware(h.authService), middleware.ProfileAccessMiddleware(h.userService, "userId"), h.GetUserProgress)
+	}
+
+	moderation := router.Group("/moderation/series")
+	moderation.Use(middleware.AuthMiddleware(h.authService), middleware.AdminMiddleware(h.authService))
+	{
+		moderation.POST("", h.CreateSeries)
+		moderation.POST("/populate", h.PopulateSeries)
+		moderation.PATCH("/:id", h.UpdateSeries)
+		moderation.PUT("/:id/games", h.SetSeriesGames)
+		moderation.DELETE("/:id", h.DeleteSeries)
+	}
+}
+
+func (h *SeriesHandler) ListSeries(ctx *gin.Context) {
+	limit, offset := getPagination(ctx)
+	series, total, err := h.seriesService.ListSeries(ctx.Query("q"), limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch series"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   series,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *SeriesHandler) GetSeries(ctx *gin.Context) {
+	series, err := h.seriesService.GetSeries(ctx.Param("id"))
+	if err != nil {
+		respondSeriesError(ctx, err, "failed to fetch series")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, series)
+}
+
+func (h *SeriesHandler) GetUserProgress(ctx *gin.Context) {
+	viewerID, _ := middleware.GetUserID(ctx)
+	progress, err := h.seriesService.GetUserProgress(ctx.Param("id"), ctx.Param("userId"), viewerID)
+	if err != nil {
+		respondSeriesError(ctx, err, "failed to fetch series progress")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, progress)
+}
+
+func (h *SeriesHandler) CreateSeries(ctx *gin.Context) {
+	var req struct {
+		Name        string   `json:"name" binding:"required"`
+		Description string   `json:"description"`
+		GameIDs     []string `json:"gameIds"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	series, err := h.seriesService.CreateSeries(services.SeriesInput{
+		Name:        req.Name,
+		Description: req.Description,
+		GameIDs:     req.GameIDs,
+	})
+	if err != nil {
+		respondSeriesError(ctx, err, "failed to create series")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, series)
+}
+
+func (h *SeriesHandler) UpdateSeries(ctx *gin.Context) {
+	var req struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	series, err := h.seriesService.UpdateSeries(ctx.Param("id"), req.Name, req.Description)
+	if err != nil {
+		respondSeriesError(ctx, err, "failed to update series")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, series)
+}
+
+func (h *SeriesHandler) SetSeriesGames(ctx *gin.Context) {
+	var req struct {
+		GameIDs []string `json:"gameIds" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	series, err := h.seriesService.SetSeriesGames(ctx.Param("id"), req.GameIDs)
+	if err != nil {
+		respondSeriesError(ctx, err, "failed to update series games")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, series)
+}
+
+func (h *SeriesHandler) DeleteSeries(ctx *gin.Context) {
+	if err := h.seriesService.DeleteSeries(ctx.Param("id")); err != nil {
+		respondSeriesError(ctx, err, "failed to delete series")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "series deleted"})
+}
+
+func (h *SeriesHandler) PopulateSeries(ctx *gin.Context) {
+	result, err := h.seriesService.PopulateFromSteam()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to populate series"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func respondSeriesError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "series not found"})
+	case errors.Is(err, services.ErrSeriesExists):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidSeriesName),
+		errors.Is(err, services.ErrInvalidSeriesGames):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
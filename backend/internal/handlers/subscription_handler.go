@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"gamecheck/internal/domain/models"
 	"gamecheck/internal/infra/db/repositories"
@@ -15,69 +16,111 @@ import (
 type SubscriptionHandler struct {
 	subscriptionRepository *repositories.SubscriptionRepository
 	activityService        *services.ActivityService
+	notificationService    *services.NotificationService
 	authService            *services.AuthService
+	userService            *services.UserService
+}
+
+type FollowListUserResponse struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"displayName"`
+	AvatarURL   string    `json:"avatarUrl"`
+	FollowedAt  time.Time `json:"followedAt"`
+	FollowsYou  bool      `json:"followsYou"`
+	YouFollow   bool      `json:"youFollow"`
+}
+
+type FollowRequestResponse struct {
+	User        services.ActivityUser `json:"user"`
+	RequestedAt time.Time             `json:"requestedAt"`
 }
 
 func NewSubscriptionHandler(
 	subscriptionRepo *repositories.SubscriptionRepository,
 	activityService *services.ActivityService,
+	notificationService *services.NotificationService,
 	authService *services.AuthService,
+	userService *services.UserService,
 ) *SubscriptionHandler {
 	return &SubscriptionHandler{
 		subscriptionRepository: subscriptionRepo,
 		activityService:        activityService,
+		notificationService:    notificationService,
 		authService:            authService,
+		userService:            userService,
 	}
 }
 
 func (h *SubscriptionHandler) RegisterRoutes(router *gin.RouterGroup) {
 	subs := router.Group("/subscriptions")
 	{
-		subs.GET("/:userId/followers", h.GetFollowers)
-		subs.GET("/:userId/following", h.GetFollowing)
+		subs.GET("/:userId/followers", middleware.OptionalAuthMiddleware(h.authService), middleware.ProfileAccessMiddleware(h.userService, "userId"), h.GetFollowers)
+		subs.GET("/:userId/following", middleware.OptionalAuthMiddleware(h.authService), middleware.ProfileAccessMiddleware(h.userService, "userId"), h.GetFollowing)
+		subs.GET("/:userId/mutual", middleware.OptionalAuthMiddleware(h.authService), middleware.ProfileAccessMiddleware(h.userService, "userId"), h.GetMutual)
 		subs.POST("/follow/:userId", middleware.AuthMiddleware(h.authService), h.Follow)
 		subs.DELETE("/unfollow/:userId", middleware.AuthMiddleware(h.authService), h.Unfollow)
+		subs.GET("/requests", middleware.AuthMiddleware(h.authService), h.GetFollowRequests)
+		subs.POST("/requests/:userId/approve", middleware.AuthMiddleware(h.authService), h.ApproveFollowRequest)
+		subs.POST("/requests/:userId/deny", middleware.AuthMiddleware(h.authService), h.DenyFollowRequest)
+		subs.DELETE("/followers/:userId", middleware.AuthMiddleware(h.authService), h.RemoveFollower)
 	}
 }
 
 func (h *SubscriptionHandler) GetFollowers(ctx *gin.Context) {
-	userID := ctx.Param("userId")
-	if userID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
-		return
-	}
-
-	followers, err := h.subscriptionRepository.GetFollowers(userID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch followers"})
-		return
-	}
+	h.respondFollowList(ctx, repositories.FollowListFollowers, "failed to fetch followers")
+}
 
-	if followers == nil {
-		followers = []*models.User{}
-	}
+func (h *SubscriptionHandler) GetFollowing(ctx *gin.Context) {
+	h.respondFollowList(ctx, repositories.FollowListFollowing, "failed to fetch following")
+}
 
-	ctx.JSON(http.StatusOK, followers)
+func (h *SubscriptionHandler) GetMutual(ctx *gin.Context) {
+	h.respondFollowList(ctx, repositories.FollowListMutual, "failed to fetch mutual follows")
 }
 
-func (h *SubscriptionHandler) GetFollowing(ctx *gin.Context) {
+func (h *SubscriptionHandler) respondFollowList(ctx *gin.Context, kind repositories.FollowListKind, failure string) {
 	userID := ctx.Param("userId")
 	if userID == "" {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
 		return
 	}
 
-	following, err := h.subscriptionRepository.GetFollowing(userID)
+	viewerID, _ := middleware.GetUserID(ctx)
+	search := ctx.Query("q")
+	order := ctx.DefaultQuery("order", "desc")
+
+	limit, offset := getPagination(ctx)
+
+	rows, err := h.subscriptionRepository.ListFollowList(kind, userID, viewerID, search, order, limit, offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch following"})
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": failure})
 		return
 	}
 
-	if following == nil {
-		following = []*models.User{}
+	users := make([]FollowListUserResponse, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, FollowListUserResponse{
+			ID:          row.UserID,
+			DisplayName: row.DisplayName,
+			AvatarURL:   row.AvatarURL,
+			FollowedAt:  row.FollowedAt,
+			FollowsYou:  row.FollowsYou,
+			YouFollow:   row.YouFollow,
+		})
+	}
+
+	total, err := h.subscriptionRepository.CountFollowList(kind, userID, viewerID, search)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, following)
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   users,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
 }
 
 func (h *SubscriptionHandler) Follow(ctx *gin.Context) {
@@ -98,16 +141,41 @@ func (h *SubscriptionHandler) Follow(ctx *gin.Context) {
 		return
 	}
 
-	isFollowing, _ := h.subscriptionRepository.IsFollowing(followerID, followingID)
-	if isFollowing {
+	target, err := h.userService.GetUser(followingID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+
+	blocked, err := h.userService.IsBlockedEither(followerID, followingID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to follow user"})
+		return
+	}
+	if blocked {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "cannot follow this user"})
+		return
+	}
+
+	if existing, err := h.subscriptionRepository.GetByUsers(followerID, followingID); err == nil {
+		if existing.Status == models.SubscriptionStatusPending {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "follow request already sent"})
+			return
+		}
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "already following"})
 		return
 	}
 
+	status := models.SubscriptionStatusAccepted
+	if target.ProfileVisibility == models.ProfileVisibilityPrivate {
+		status = models.SubscriptionStatusPending
+	}
+
 	sub := &models.Subscription{
 		ID:          uuid.New().String(),
 		FollowerID:  followerID,
 		FollowingID: followingID,
+		Status:      status,
 	}
 
 	if err := h.subscriptionRepository.Create(sub); err != nil {
@@ -115,9 +183,24 @@ func (h *SubscriptionHandler) Follow(ctx *gin.Context) {
 		return
 	}
 
+	if status == models.SubscriptionStatusPending {
+		h.notificationService.Dispatch(services.NotificationEvent{
+			Type:        models.NotificationTypeFollowRequest,
+			ActorID:     followerID,
+			RecipientID: followingID,
+		})
+		ctx.JSON(http.StatusOK, gin.H{"message": "follow requested", "status": status})
+		return
+	}
+
 	h.activityService.Follow(followerID, followingID)
+	h.notificationService.Dispatch(services.NotificationEvent{
+		Type:        models.NotificationTypeNewFollower,
+		ActorID:     followerID,
+		RecipientID: followingID,
+	})
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "followed"})
+	ctx.JSON(http.StatusOK, gin.H{"message": "followed", "status": status})
 }
 
 func (h *SubscriptionHandler) Unfollow(ctx *gin.Context) {
@@ -140,3 +223,111 @@ func (h *SubscriptionHandler) Unfollow(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "unfollowed"})
 }
+
+func (h *SubscriptionHandler) GetFollowRequests(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	limit, offset := getPagination(ctx)
+	rows, err := h.subscriptionRepository.GetPendingRequests(userID, limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch follow requests"})
+		return
+	}
+
+	total, err := h.subscriptionRepository.CountPendingRequests(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch follow requests"})
+		return
+	}
+
+	requests := make([]FollowRequestResponse, 0, len(rows))
+	for _, row := range rows {
+		requests = append(requests, FollowRequestResponse{
+			User: services.ActivityUser{
+				ID:          row.UserID,
+				DisplayName: row.DisplayName,
+				AvatarURL:   row.AvatarURL,
+			},
+			RequestedAt: row.RequestedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   requests,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *SubscriptionHandler) ApproveFollowRequest(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	followerID := ctx.Param("userId")
+	approved, err := h.subscriptionRepository.Approve(followerID, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to approve follow request"})
+		return
+	}
+	if approved == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "follow request not found"})
+		return
+	}
+
+	h.activityService.Follow(followerID, userID)
+	h.notificationService.Dispatch(services.NotificationEvent{
+		Type:        models.NotificationTypeFollowApproved,
+		ActorID:     userID,
+		RecipientID: followerID,
+	})
+	h.notificationService.Dispatch(services.NotificationEvent{
+		Type:        models.NotificationTypeNewFollower,
+		ActorID:     followerID,
+		RecipientID: userID,
+	})
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "follow request approved"})
+}
+
+func (h *SubscriptionHandler) DenyFollowRequest(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	denied, err := h.subscriptionRepository.DeletePending(ctx.Param("userId"), userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to deny follow request"})
+		return
+	}
+	if denied == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "follow request not found"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "follow request denied"})
+}
+
+func (h *SubscriptionHandler) RemoveFollower(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.subscriptionRepository.DeleteByUsers(ctx.Param("userId"), userID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove follower"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "follower removed"})
+}
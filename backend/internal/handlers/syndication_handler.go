@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gamecheck/internal/config"
+	"gamecheck/internal/middleware"
+	"gamecheck/internal/services"
+	"gamecheck/pkg/feeds"
+
+	"github.com/gin-gonic/gin"
+)
+
+const syndicationMaxAge = 5 * time.Minute
+
+type SyndicationHandler struct {
+	config             *config.Config
+	syndicationService *services.SyndicationService
+}
+
+func NewSyndicationHandler(
+	cfg *config.Config,
+	syndicationService *services.SyndicationService,
+) *SyndicationHandler {
+	return &SyndicationHandler{
+		config:             cfg,
+		syndicationService: syndicationService,
+	}
+}
+
+func (h *SyndicationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/:id/activity.atom", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetUserActivityAtom)
+	router.GET("/users/:id/activity.rss", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetUserActivityRSS)
+	router.GET("/library/app/:appId/reviews.atom", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.GetGameReviewsAtom)
+}
+
+func (h *SyndicationHandler) GetUserActivityAtom(ctx *gin.Context) {
+	feed, err := h.syndicationService.UserActivityFeed(ctx.Param("id"), h.selfLink(ctx))
+	if err != nil {
+		respondSyndicationError(ctx, err)
+		return
+	}
+
+	writeFeed(ctx, feed, feeds.Atom, "application/atom+xml; charset=utf-8")
+}
+
+func (h *SyndicationHandler) GetUserActivityRSS(ctx *gin.Context) {
+	feed, err := h.syndicationService.UserActivityFeed(ctx.Param("id"), h.selfLink(ctx))
+	if err != nil {
+		respondSyndicationError(ctx, err)
+		return
+	}
+
+	writeFeed(ctx, feed, feeds.RSS, "application/rss+xml; charset=utf-8")
+}
+
+func (h *SyndicationHandler) GetGameReviewsAtom(ctx *gin.Context) {
+	appID, err := strconv.Atoi(ctx.Param("appId"))
+	if err != nil || appID <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid app id"})
+		return
+	}
+
+	feed, err := h.syndicationService.GameReviewsFeed(appID, h.selfLink(ctx))
+	if err != nil {
+		respondSyndicationError(ctx, err)
+		return
+	}
+
+	writeFeed(ctx, feed, feeds.Atom, "application/atom+xml; charset=utf-8")
+}
+
+func (h *SyndicationHandler) selfLink(ctx *gin.Context) string {
+	return strings.TrimRight(h.config.URLS.Backend, "/") + ctx.Request.URL.Path
+}
+
+func respondSyndicationError(ctx *gin.Context, err error) {
+	if errors.Is(err, services.ErrFeedNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, services.ErrProfilePrivate) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build feed"})
+}
+
+func writeFeed(ctx *gin.Context, feed *feeds.Feed, render func(*feeds.Feed) ([]byte, error), contentType string) {
+	body, err := render(feed)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to render feed"})
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	lastModified := feed.Updated.UTC().Truncate(time.Second)
+
+	ctx.Header("ETag", etag)
+	ctx.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	ctx.Header("Cache-Control", "public, max-age="+strconv.Itoa(int(syndicationMaxAge.Seconds())))
+
+	if feedNotModified(ctx, etag, lastModified) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, body)
+}
+
+func feedNotModified(ctx *gin.Context, etag string, lastModified time.Time) bool {
+	if match := ctx.GetHeader("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if since := ctx.GetHeader("If-Modified-Since"); since != "" {
+		parsed, err := http.ParseTime(since)
+		if err == nil && !lastModified.After(parsed) {
+			return true
+		}
+	}
+	return false
+}
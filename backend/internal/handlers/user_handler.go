@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"regexp"
 	"strings"
 
+	"gamecheck/internal/domain/models"
 	"gamecheck/internal/middleware"
 	"gamecheck/internal/services"
 
@@ -12,17 +14,20 @@ import (
 )
 
 type UserHandler struct {
-	userService *services.UserService
-	authService *services.AuthService
+	userService          *services.UserService
+	authService          *services.AuthService
+	compatibilityService *services.CompatibilityService
 }
 
 func NewUserHandler(
 	userService *services.UserService,
 	authService *services.AuthService,
+	compatibilityService *services.CompatibilityService,
 ) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		authService: authService,
+		userService:          userService,
+		authService:          authService,
+		compatibilityService: compatibilityService,
 	}
 }
 
@@ -32,7 +37,14 @@ func (h *UserHandler) RegisterRoutes(router *gin.RouterGroup) {
 		users.GET("", h.ListUsers)
 		users.GET("/:id", middleware.OptionalAuthMiddleware(h.authService), h.GetProfile)
 		users.PATCH("/profile", middleware.AuthMiddleware(h.authService), middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.UpdateProfile)
-		users.GET("/search/:query", h.SearchUsers)
+		users.GET("/search/:query", middleware.OptionalAuthMiddleware(h.authService), h.SearchUsers)
+		users.GET("/blocks", middleware.AuthMiddleware(h.authService), h.ListBlocked)
+		users.POST("/:id/block", middleware.AuthMiddleware(h.authService), h.Block)
+		users.DELETE("/:id/block", middleware.AuthMiddleware(h.authService), h.Unblock)
+		users.GET("/mutes", middleware.AuthMiddleware(h.authService), h.ListMuted)
+		users.POST("/:id/mute", middleware.AuthMiddleware(h.authService), h.Mute)
+		users.DELETE("/:id/mute", middleware.AuthMiddleware(h.authService), h.Unmute)
+		users.GET("/:id/compare/:otherId", middleware.OptionalAuthMiddleware(h.authService), middleware.ProfileAccessMiddleware(h.userService, "id"), middleware.ProfileAccessMiddleware(h.userService, "otherId"), h.Compare)
 	}
 }
 
@@ -78,17 +90,24 @@ func (h *UserHandler) ListUsers(ctx *gin.Context) {
 		req.Limit = 10
 	}
 
-	users, total, err := h.userService.ListUsers(req.Limit, req.Offset, req.Sort, req.Order)
+	cursor, _, err := getCursor(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+
+	users, total, nextCursor, err := h.userService.ListUsers(cursor, req.Limit, req.Offset, req.Sort, req.Order)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch users"})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"data":   users,
-		"total":  total,
-		"limit":  req.Limit,
-		"offset": req.Offset,
+		"data":       users,
+		"total":      total,
+		"limit":      req.Limit,
+		"offset":     req.Offset,
+		"nextCursor": nextCursor,
 	})
 }
 
@@ -100,8 +119,9 @@ func (h *UserHandler) UpdateProfile(ctx *gin.Context) {
 	}
 
 	var req struct {
-		DisplayName *string `json:"displayName"`
-		DiscordTag  *string `json:"discordTag"`
+		DisplayName       *string `json:"displayName"`
+		DiscordTag        *string `json:"discordTag"`
+		ProfileVisibility *string `json:"profileVisibility"`
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -139,7 +159,17 @@ func (h *UserHandler) UpdateProfile(ctx *gin.Context) {
 		user.DiscordTag = *req.DiscordTag
 	}
 
-	if err := h.userService.UpdateUser(user); err != nil {
+	if req.ProfileVisibility != nil {
+		visibility := models.ProfileVisibility(*req.ProfileVisibility)
+		if !visibility.IsValid() {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": services.ErrInvalidProfileVisibility.Error()})
+			return
+		}
+		if err := h.userService.UpdateProfileVisibility(user, visibility); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update profile"})
+			return
+		}
+	} else if err := h.userService.UpdateUser(user); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update profile"})
 		return
 	}
@@ -154,7 +184,8 @@ func (h *UserHandler) SearchUsers(ctx *gin.Context) {
 		return
 	}
 
-	users, err := h.userService.SearchUsers(query, 10)
+	viewerID, _ := middleware.GetUserID(ctx)
+	users, err := h.userService.SearchUsers(query, viewerID, 10)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "search failed"})
 		return
@@ -162,3 +193,135 @@ func (h *UserHandler) SearchUsers(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, users)
 }
+
+func (h *UserHandler) Block(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.userService.BlockUser(userID, ctx.Param("id")); err != nil {
+		respondUserRelationError(ctx, err, "failed to block user")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "user blocked"})
+}
+
+func (h *UserHandler) Unblock(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.userService.UnblockUser(userID, ctx.Param("id")); err != nil {
+		respondUserRelationError(ctx, err, "failed to unblock user")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "user unblocked"})
+}
+
+func (h *UserHandler) ListBlocked(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	limit, offset := getPagination(ctx)
+	users, total, err := h.userService.ListBlockedUsers(userID, limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch blocked users"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   users,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *UserHandler) Mute(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.userService.MuteUser(userID, ctx.Param("id")); err != nil {
+		respondUserRelationError(ctx, err, "failed to mute user")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "user muted"})
+}
+
+func (h *UserHandler) Unmute(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.userService.UnmuteUser(userID, ctx.Param("id")); err != nil {
+		respondUserRelationError(ctx, err, "failed to unmute user")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "user unmuted"})
+}
+
+func (h *UserHandler) ListMuted(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	limit, offset := getPagination(ctx)
+	users, total, err := h.userService.ListMutedUsers(userID, limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch muted users"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   users,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *UserHandler) Compare(ctx *gin.Context) {
+	userID := ctx.Param("id")
+	otherID := ctx.Param("otherId")
+	if userID == otherID {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "cannot compare a user with themselves"})
+		return
+	}
+
+	result, err := h.compatibilityService.Compare(userID, otherID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compare users"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func respondUserRelationError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCannotBlockSelf), errors.Is(err, services.ErrCannotMuteSelf):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
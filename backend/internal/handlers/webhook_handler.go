@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/middleware"
+	"gamecheck/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+	authService    *services.AuthService
+}
+
+func NewWebhookHandler(
+	webhookService *services.WebhookService,
+	authService *services.AuthService,
+) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		authService:    authService,
+	}
+}
+
+func (h *WebhookHandler) RegisterRoutes(router *gin.RouterGroup) {
+	webhooks := router.Group("/webhooks")
+	webhooks.Use(middleware.AuthMiddleware(h.authService))
+	{
+		webhooks.GET("", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.List)
+		webhooks.POST("", middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.Create)
+		webhooks.PATCH("/:id", middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.Update)
+		webhooks.DELETE("/:id", middleware.RateLimitByUserOrIPFromContext("deleteLimiter"), h.Delete)
+		webhooks.POST("/:id/rotate-secret", middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.RotateSecret)
+		webhooks.POST("/:id/test", middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.Test)
+		webhooks.GET("/:id/deliveries", middleware.RateLimitByUserOrIPFromContext("readLimiter"), h.ListDeliveries)
+		webhooks.POST("/:id/deliveries/:deliveryId/retry", middleware.RateLimitByUserOrIPFromContext("gameAddUpdateLimiter"), h.RetryDelivery)
+	}
+}
+
+type webhookRequest struct {
+	URL    *string  `json:"url"`
+	Events []string `json:"events"`
+	Format *string  `json:"format"`
+	Active *bool    `json:"active"`
+}
+
+func (r webhookRequest) input() services.WebhookInput {
+	return services.WebhookInput{
+		URL:    r.URL,
+		Events: r.Events,
+		Format: r.Format,
+		Active: r.Active,
+	}
+}
+
+func (h *WebhookHandler) List(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	webhooks, err := h.webhookService.List(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch webhooks"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+func (h *WebhookHandler) Create(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req webhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	webhook, err := h.webhookService.Create(userID, req.input())
+	if err != nil {
+		respondWebhookError(ctx, err, "failed to create webhook")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, webhook)
+}
+
+func (h *WebhookHandler) Update(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req webhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	webhook, err := h.webhookService.Update(ctx.Param("id"), userID, req.input())
+	if err != nil {
+		respondWebhookError(ctx, err, "failed to update webhook")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) Delete(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.webhookService.Delete(ctx.Param("id"), userID); err != nil {
+		respondWebhookError(ctx, err, "failed to delete webhook")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "webhook deleted"})
+}
+
+func (h *WebhookHandler) RotateSecret(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	webhook, err := h.webhookService.RotateSecret(ctx.Param("id"), userID)
+	if err != nil {
+		respondWebhookError(ctx, err, "failed to rotate webhook secret")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+func (h *WebhookHandler) Test(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	delivery, err := h.webhookService.Test(ctx.Param("id"), userID)
+	if err != nil {
+		respondWebhookError(ctx, err, "failed to send test webhook")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delivery)
+}
+
+func (h *WebhookHandler) ListDeliveries(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	status := ctx.Query("status")
+	switch models.WebhookDeliveryStatus(status) {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliveryDelivered, models.WebhookDeliveryDead:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+
+	limit, offset := getPagination(ctx)
+	page, err := h.webhookService.ListDeliveries(ctx.Param("id"), userID, status, limit, offset)
+	if err != nil {
+		respondWebhookError(ctx, err, "failed to fetch webhook deliveries")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+func (h *WebhookHandler) RetryDelivery(ctx *gin.Context) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	delivery, err := h.webhookService.RetryDelivery(ctx.Param("id"), ctx.Param("deliveryId"), userID)
+	if err != nil {
+		respondWebhookError(ctx, err, "failed to retry webhook delivery")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delivery)
+}
+
+func respondWebhookError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWebhookNotFound), errors.Is(err, services.ErrWebhookDeliveryNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidWebhookURL),
+		errors.Is(err, services.ErrInvalidWebhookEvents),
+		errors.Is(err, services.ErrInvalidWebhookFormat):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrWebhookLimitReached), errors.Is(err, services.ErrWebhookDeliveryNotDead):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -14,7 +14,8 @@ import (
 )
 
 type Database struct {
-	db *gorm.DB
+	db  *gorm.DB
+	dsn string
 }
 
 func New(cfg *config.Config) (*Database, error) {
@@ -50,7 +51,7 @@ func New(cfg *config.Config) (*Database, error) {
 
 	log.Println("Successfully connected to database")
 
-	database := &Database{db: db}
+	database := &Database{db: db, dsn: dsn}
 	if err := database.Migrate(); err != nil {
 		return nil, err
 	}
@@ -66,11 +67,47 @@ func (d *Database) Migrate() error {
 		&models.LibraryGame{},
 		&models.Token{},
 		&models.Subscription{},
+		&models.ReviewVote{},
+		&models.ReviewReport{},
+		&models.ActivityLike{},
+		&models.ActivityComment{},
+		&models.Notification{},
+		&models.NotificationPreference{},
+		&models.ArchivedActivity{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.UserBlock{},
+		&models.UserMute{},
+		&models.Recommendation{},
+		&models.RecommendationRun{},
+		&models.SimilarGame{},
+		&models.SimilarGameRun{},
+		&models.GameChartEntry{},
+		&models.LibraryGameRedirect{},
+		&models.GameSeries{},
+		&models.GameSeriesEntry{},
+		&models.ProgressImportJob{},
 	); err != nil {
 		return err
 	}
 
-	return d.ensureActivityTrimTrigger()
+	if err := d.dropActivityTrimTrigger(); err != nil {
+		return err
+	}
+
+	if err := d.ensureRealtimeTriggers(); err != nil {
+		return err
+	}
+
+	if err := d.ensureSearchIndexes(); err != nil {
+		return err
+	}
+
+	if err := d.ensureLibraryGameLinks(); err != nil {
+		return err
+	}
+
+	return d.ensureWebhookTrigger()
 }
 
 func (d *Database) GetDB() *gorm.DB {
@@ -85,19 +122,25 @@ func (d *Database) Close() error {
 	return sqlDB.Close()
 }
 
-func (d *Database) ensureActivityTrimTrigger() error {
+func (d *Database) dropActivityTrimTrigger() error {
+	if err := d.db.Exec(`DROP TRIGGER IF EXISTS activities_trim_trigger ON activities;`).Error; err != nil {
+		return err
+	}
+	return d.db.Exec(`DROP FUNCTION IF EXISTS trim_user_activities();`).Error
+}
+
+func (d *Database) ensureRealtimeTriggers() error {
 	createFn := `
-CREATE OR REPLACE FUNCTION trim_user_activities() RETURNS trigger AS $$
+CREATE OR REPLACE FUNCTION notify_realtime_event() RETURNS trigger AS $$
 BEGIN
-	DELETE FROM activities
-	WHERE user_id = NEW.user_id
-	  AND id NOT IN (
-		SELECT id
-		FROM activities
-		WHERE user_id = NEW.user_id
-		ORDER BY created_at DESC, id DESC
-		LIMIT 30
-	  );
+	PERFORM pg_notify(
+		'` + RealtimeChannel + `',
+		json_build_object(
+			'kind', TG_ARGV[0],
+			'id', NEW.id,
+			'userId', NEW.user_id
+		)::text
+	);
 	RETURN NEW;
 END;
 $$ LANGUAGE plpgsql;
@@ -107,16 +150,145 @@ $$ LANGUAGE plpgsql;
 		return err
 	}
 
-	if err := d.db.Exec(`DROP TRIGGER IF EXISTS activities_trim_trigger ON activities;`).Error; err != nil {
-		return err
+	triggers := []struct {
+		name  string
+		table string
+		kind  string
+	}{
+		{name: "activities_realtime_trigger", table: "activities", kind: "activity"},
+		{name: "notifications_realtime_trigger", table: "notifications", kind: "notification"},
 	}
 
-	createTrigger := `
-CREATE TRIGGER activities_trim_trigger
-AFTER INSERT ON activities
+	for _, trigger := range triggers {
+		if err := d.db.Exec(fmt.Sprintf(`DROP TRIGGER IF EXISTS %s ON %s;`, trigger.name, trigger.table)).Error; err != nil {
+			return err
+		}
+
+		createTrigger := fmt.Sprintf(`
+CREATE TRIGGER %s
+AFTER INSERT ON %s
+FOR EACH ROW
+EXECUTE PROCEDURE notify_realtime_event('%s');
+`, trigger.name, trigger.table, trigger.kind)
+
+		if err := d.db.Exec(createTrigger).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Database) ensureSearchIndexes() error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm;`,
+		`ALTER TABLE library_games ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+	setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+	setweight(to_tsvector('simple', COALESCE(short_description, '')), 'B') ||
+	setweight(to_tsvector('simple', COALESCE(description, '')), 'C')
+) STORED;`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+	to_tsvector('simple', COALESCE(display_name, ''))
+) STORED;`,
+		`ALTER TABLE progresses ADD COLUMN IF NOT EXISTS review_search_vector tsvector GENERATED ALWAYS AS (
+	to_tsvector('simple', COALESCE(review, ''))
+) STORED;`,
+		`CREATE INDEX IF NOT EXISTS idx_library_games_search_vector ON library_games USING gin (search_vector);`,
+		`CREATE INDEX IF NOT EXISTS idx_users_search_vector ON users USING gin (search_vector);`,
+		`CREATE INDEX IF NOT EXISTS idx_progresses_review_search_vector ON progresses USING gin (review_search_vector);`,
+		`CREATE INDEX IF NOT EXISTS idx_library_games_name_trgm ON library_games USING gin (name gin_trgm_ops);`,
+		`CREATE INDEX IF NOT EXISTS idx_users_display_name_trgm ON users USING gin (display_name gin_trgm_ops);`,
+		`CREATE INDEX IF NOT EXISTS idx_progresses_review_trgm ON progresses USING gin (review gin_trgm_ops);`,
+	}
+
+	for _, statement := range statements {
+		if err := d.db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Database) ensureLibraryGameLinks() error {
+	statements := []string{
+		`ALTER TABLE library_games ALTER COLUMN steam_app_id DROP NOT NULL;`,
+		`UPDATE progresses
+SET library_game_id = library_games.id
+FROM library_games
+WHERE progresses.library_game_id IS NULL
+  AND progresses.steam_app_id = library_games.steam_app_id;`,
+		`CREATE OR REPLACE FUNCTION link_progress_library_game() RETURNS trigger AS $$
+BEGIN
+	IF NEW.steam_app_id IS NOT NULL AND (
+		(TG_OP = 'INSERT' AND NEW.library_game_id IS NULL) OR
+		(TG_OP = 'UPDATE' AND NEW.steam_app_id IS DISTINCT FROM OLD.steam_app_id)
+	) THEN
+		NEW.library_game_id := COALESCE(
+			(SELECT id FROM library_games WHERE steam_app_id = NEW.steam_app_id),
+			(SELECT target_id FROM library_game_redirects WHERE steam_app_id = NEW.steam_app_id)
+		);
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;`,
+		`DROP TRIGGER IF EXISTS progresses_library_game_trigger ON progresses;`,
+		`CREATE TRIGGER progresses_library_game_trigger
+BEFORE INSERT OR UPDATE OF steam_app_id ON progresses
 FOR EACH ROW
-EXECUTE PROCEDURE trim_user_activities();
+EXECUTE PROCEDURE link_progress_library_game();`,
+		`CREATE OR REPLACE FUNCTION link_library_game_progresses() RETURNS trigger AS $$
+BEGIN
+	IF NEW.steam_app_id IS NOT NULL THEN
+		UPDATE progresses
+		SET library_game_id = NEW.id
+		WHERE steam_app_id = NEW.steam_app_id
+		  AND library_game_id IS NULL;
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;`,
+		`DROP TRIGGER IF EXISTS library_games_progress_link_trigger ON library_games;`,
+		`CREATE TRIGGER library_games_progress_link_trigger
+AFTER INSERT OR UPDATE OF steam_app_id ON library_games
+FOR EACH ROW
+EXECUTE PROCEDURE link_library_game_progresses();`,
+	}
+
+	for _, statement := range statements {
+		if err := d.db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Database) ensureWebhookTrigger() error {
+	createFn := `
+CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries() RETURNS trigger AS $$
+BEGIN
+	INSERT INTO webhook_deliveries (id, webhook_id, activity_id, event, status, attempts, next_attempt_at, created_at)
+	SELECT gen_random_uuid(), webhooks.id, NEW.id, NEW.type, 'pending', 0, NOW(), NOW()
+	FROM webhooks
+	WHERE webhooks.user_id = NEW.user_id
+	  AND webhooks.active
+	  AND webhooks.events ? NEW.type;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
 `
 
-	return d.db.Exec(createTrigger).Error
+	if err := d.db.Exec(createFn).Error; err != nil {
+		return err
+	}
+
+	if err := d.db.Exec(`DROP TRIGGER IF EXISTS activities_webhook_trigger ON activities;`).Error; err != nil {
+		return err
+	}
+
+	return d.db.Exec(`
+CREATE TRIGGER activities_webhook_trigger
+AFTER INSERT ON activities
+FOR EACH ROW
+EXECUTE PROCEDURE enqueue_webhook_deliveries();
+`).Error
 }
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	RealtimeChannel       = "gamecheck_events"
+	listenerRetryInterval = 5 * time.Second
+)
+
+func (d *Database) Listen(ctx context.Context, channel string, handler func(payload string)) {
+	for {
+		if err := d.listen(ctx, channel, handler); err != nil && ctx.Err() == nil {
+			log.Printf("listener on %s stopped: %v", channel, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenerRetryInterval):
+		}
+	}
+}
+
+func (d *Database) listen(ctx context.Context, channel string, handler func(payload string)) error {
+	conn, err := pgx.Connect(ctx, d.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handler(notification.Payload)
+	}
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ActivityCommentRepository struct {
+	db *gorm.DB
+}
+
+type ActivityCommentRow struct {
+	ID              string     `gorm:"column:id"`
+	ActivityID      string     `gorm:"column:activity_id"`
+	ParentID        *string    `gorm:"column:parent_id"`
+	UserID          string     `gorm:"column:user_id"`
+	UserDisplayName string     `gorm:"column:user_display_name"`
+	UserAvatarURL   string     `gorm:"column:user_avatar_url"`
+	Body            string     `gorm:"column:body"`
+	EditedAt        *time.Time `gorm:"column:edited_at"`
+	CreatedAt       time.Time  `gorm:"column:created_at"`
+}
+
+func NewActivityCommentRepository(db *gorm.DB) *ActivityCommentRepository {
+	return &ActivityCommentRepository{db: db}
+}
+
+func (r *ActivityCommentRepository) Create(comment *models.ActivityComment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *ActivityCommentRepository) GetByID(id string) (*models.ActivityComment, error) {
+	var comment models.ActivityComment
+	if err := r.db.First(&comment, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *ActivityCommentRepository) UpdateBody(id, body string) error {
+	return r.db.Model(&models.ActivityComment{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"body":      body,
+			"edited_at": time.Now(),
+		}).Error
+}
+
+func (r *ActivityCommentRepository) Delete(id string) error {
+	return r.db.Delete(&models.ActivityComment{}, "id = ?", id).Error
+}
+
+func (r *ActivityCommentRepository) ListRootsByActivityID(activityID, viewerID string, limit, offset int) ([]ActivityCommentRow, error) {
+	var rows []ActivityCommentRow
+	condition, args := commentVisibleCondition(viewerID)
+	err := r.commentQuery().
+		Where("activity_comments.activity_id = ?", activityID).
+		Where("activity_comments.parent_id IS NULL").
+		Where(condition, args...).
+		Order("activity_comments.created_at ASC, activity_comments.id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ActivityCommentRepository) CountRootsByActivityID(activityID, viewerID string) (int64, error) {
+	var count int64
+	condition, args := commentVisibleCondition(viewerID)
+	err := r.db.Model(&models.ActivityComment{}).
+		Where("activity_comments.activity_id = ? AND activity_comments.parent_id IS NULL", activityID).
+		Where(condition, args...).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *ActivityCommentRepository) ListRepliesByRootIDs(rootIDs []string, viewerID string) ([]ActivityCommentRow, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	condition, args := commentVisibleCondition(viewerID)
+	threadArgs := append([]interface{}{rootIDs}, args...)
+	threadArgs = append(threadArgs, args...)
+	thread := r.db.Raw(`
+		WITH RECURSIVE thread AS (
+			SELECT id FROM activity_comments WHERE parent_id IN ? AND `+condition+`
+			UNION ALL
+			SELECT activity_comments.id
+			FROM activity_comments
+			JOIN thread ON activity_comments.parent_id = thread.id
+			WHERE `+condition+`
+		)
+		SELECT id FROM thread
+	`, threadArgs...)
+
+	var rows []ActivityCommentRow
+	err := r.commentQuery().
+		Where("activity_comments.id IN (?)", thread).
+		Order("activity_comments.created_at ASC, activity_comments.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ActivityCommentRepository) commentQuery() *gorm.DB {
+	return r.db.
+		Table("activity_comments").
+		Select(`
+			activity_comments.id,
+			activity_comments.activity_id,
+			activity_comments.parent_id,
+			activity_comments.user_id,
+			activity_comments.body,
+			activity_comments.edited_at,
+			activity_comments.created_at,
+			users.display_name AS user_display_name,
+			users.avatar_url AS user_avatar_url
+		`).
+		Joins("JOIN users ON users.id = activity_comments.user_id")
+}
+
+func commentVisibleCondition(viewerID string) (string, []interface{}) {
+	if viewerID == "" {
+		return "TRUE", nil
+	}
+	return notBlockedCondition("activity_comments.user_id", viewerID)
+}
+
+func (r *ActivityCommentRepository) AddLike(activityID, userID string) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ActivityLike{
+		ActivityID: activityID,
+		UserID:     userID,
+	}).Error
+}
+
+func (r *ActivityCommentRepository) RemoveLike(activityID, userID string) error {
+	return r.db.Delete(&models.ActivityLike{}, "activity_id = ? AND user_id = ?", activityID, userID).Error
+}
+
+func (r *ActivityCommentRepository) CountLikes(activityID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ActivityLike{}).Where("activity_id = ?", activityID).Count(&count).Error
+	return count, err
+}
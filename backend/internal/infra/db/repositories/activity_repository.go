@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"strings"
 	"time"
 
 	"gamecheck/internal/domain/models"
@@ -12,8 +13,6 @@ type ActivityRepository struct {
 	db *gorm.DB
 }
 
-const maxActivitiesPerUser = 30
-
 type ActivityRow struct {
 	ID                string              `gorm:"column:id"`
 	Type              models.ActivityType `gorm:"column:type"`
@@ -29,6 +28,9 @@ type ActivityRow struct {
 	TargetAvatarURL   *string             `gorm:"column:target_avatar_url"`
 	SteamAppID        *int                `gorm:"column:steam_app_id"`
 	SteamIconURL      string              `gorm:"column:steam_icon_url"`
+	LikesCount        int                 `gorm:"column:likes_count"`
+	CommentsCount     int                 `gorm:"column:comments_count"`
+	LikedByViewer     bool                `gorm:"column:liked_by_viewer"`
 	CreatedAt         time.Time           `gorm:"column:created_at"`
 }
 
@@ -37,13 +39,7 @@ func NewActivityRepository(db *gorm.DB) *ActivityRepository {
 }
 
 func (r *ActivityRepository) Create(activity *models.Activity) error {
-	if err := r.db.Create(activity).Error; err != nil {
-		return err
-	}
-	if activity.UserID != "" {
-		_ = r.TrimUserActivities(activity.UserID, maxActivitiesPerUser)
-	}
-	return nil
+	return r.db.Create(activity).Error
 }
 
 func (r *ActivityRepository) GetByID(id string) (*models.Activity, error) {
@@ -78,7 +74,7 @@ func (r *ActivityRepository) GetFeed(userID string, limit, offset int) ([]*model
 		Preload("User").
 		Preload("TargetUser").
 		Preload("Progress").
-		Where("user_id IN (SELECT following_id FROM subscriptions WHERE follower_id = ?) OR user_id = ?", userID, userID).
+		Where("user_id IN (SELECT following_id FROM subscriptions WHERE follower_id = ? AND status = 'accepted') OR user_id = ?", userID, userID).
 		Order("created_at DESC").
 		Offset(offset).
 		Limit(limit).
@@ -107,62 +103,146 @@ func (r *ActivityRepository) DeleteByProgressID(progressID string) error {
 	return r.db.Delete(&models.Activity{}, "progress_id = ?", progressID).Error
 }
 
-func (r *ActivityRepository) TrimUserActivities(userID string, max int) error {
-	if userID == "" || max <= 0 {
-		return nil
+func (r *ActivityRepository) Prune(maxPerUser int, olderThan *time.Time, archive bool, batchSize int) (int64, error) {
+	var conditions []string
+	var args []interface{}
+	if maxPerUser > 0 {
+		conditions = append(conditions, "ranked.position > ?")
+		args = append(args, maxPerUser)
+	}
+	if olderThan != nil {
+		conditions = append(conditions, "ranked.created_at < ?")
+		args = append(args, *olderThan)
 	}
+	if len(conditions) == 0 {
+		return 0, nil
+	}
+	args = append(args, batchSize)
 
-	return r.db.Exec(
-		`WITH keep AS (
-			SELECT id
-			FROM activities
-			WHERE user_id = ?
-			ORDER BY created_at DESC, id DESC
+	candidates := `
+		WITH candidates AS (
+			SELECT ranked.id
+			FROM (
+				SELECT
+					id,
+					created_at,
+					ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at DESC, id DESC) AS position
+				FROM activities
+			) AS ranked
+			WHERE ` + strings.Join(conditions, " OR ") + `
 			LIMIT ?
+		)`
+
+	var sql string
+	if archive {
+		sql = candidates + `,
+		pruned AS (
+			DELETE FROM activities
+			WHERE id IN (SELECT id FROM candidates)
+			RETURNING id, user_id, type, progress_id, target_user_id, game_name, status, rating, created_at
 		)
+		INSERT INTO archived_activities (id, user_id, type, progress_id, target_user_id, game_name, status, rating, created_at, archived_at)
+		SELECT id, user_id, type, progress_id, target_user_id, game_name, status, rating, created_at, NOW()
+		FROM pruned
+		ON CONFLICT (id) DO NOTHING`
+	} else {
+		sql = candidates + `
 		DELETE FROM activities
-		WHERE user_id = ?
-		  AND id NOT IN (SELECT id FROM keep)`,
-		userID,
-		max,
-		userID,
-	).Error
+		WHERE id IN (SELECT id FROM candidates)`
+	}
+
+	tx := r.db.Exec(sql, args...)
+	return tx.RowsAffected, tx.Error
 }
 
-func (r *ActivityRepository) GetFeedRows(userID string, limit, offset int) ([]ActivityRow, error) {
+func (r *ActivityRepository) GetFeedRows(userID string, cursor *Cursor, limit, offset int) ([]ActivityRow, error) {
 	var rows []ActivityRow
-	err := r.activityViewQuery().
-		Where("activities.user_id IN (SELECT following_id FROM subscriptions WHERE follower_id = ?) OR activities.user_id = ?", userID, userID).
-		Order("activities.created_at DESC").
-		Offset(offset).
-		Limit(limit).
+	query := r.activityViewQuery(userID).
+		Where("activities.user_id IN (SELECT following_id FROM subscriptions WHERE follower_id = ? AND status = 'accepted') OR activities.user_id = ?", userID, userID).
+		Where("activities.user_id NOT IN (SELECT muted_id FROM user_mutes WHERE muter_id = ?)", userID)
+	err := paginateByCreatedAt(query, "activities", cursor, limit, offset).
 		Scan(&rows).Error
 	return rows, err
 }
 
-func (r *ActivityRepository) GetByUserIDRows(userID string, limit, offset int) ([]ActivityRow, error) {
+func (r *ActivityRepository) GetFeedRowsSince(userID string, since Cursor, limit int) ([]ActivityRow, error) {
 	var rows []ActivityRow
-	err := r.activityViewQuery().
-		Where("activities.user_id = ?", userID).
-		Order("activities.created_at DESC").
-		Offset(offset).
+	err := r.activityViewQuery(userID).
+		Where("activities.user_id IN (SELECT following_id FROM subscriptions WHERE follower_id = ? AND status = 'accepted') OR activities.user_id = ?", userID, userID).
+		Where("activities.user_id NOT IN (SELECT muted_id FROM user_mutes WHERE muter_id = ?)", userID).
+		Where("(activities.created_at, activities.id) > (?, ?)", since.CreatedAt, since.ID).
+		Order("activities.created_at ASC, activities.id ASC").
 		Limit(limit).
 		Scan(&rows).Error
 	return rows, err
 }
 
-func (r *ActivityRepository) GetAllRows(limit, offset int) ([]ActivityRow, error) {
+func (r *ActivityRepository) GetByUserIDRows(userID, viewerID string, cursor *Cursor, limit, offset int) ([]ActivityRow, error) {
 	var rows []ActivityRow
-	err := r.activityViewQuery().
-		Order("activities.created_at DESC").
-		Offset(offset).
-		Limit(limit).
+	query := r.activityViewQuery(viewerID).
+		Where("activities.user_id = ?", userID)
+	err := paginateByCreatedAt(query, "activities", cursor, limit, offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ActivityRepository) GetAllRows(viewerID string, cursor *Cursor, limit, offset int) ([]ActivityRow, error) {
+	var rows []ActivityRow
+	condition, args := profileVisibleCondition("activities.user_id", "users.profile_visibility", viewerID)
+	query := r.activityViewQuery(viewerID).
+		Where(condition, args...)
+	err := paginateByCreatedAt(query, "activities", cursor, limit, offset).
 		Scan(&rows).Error
 	return rows, err
 }
 
-func (r *ActivityRepository) activityViewQuery() *gorm.DB {
-	return r.db.
+func (r *ActivityRepository) GetRowByID(id, viewerID string) (*ActivityRow, error) {
+	var row ActivityRow
+	tx := r.activityViewQuery(viewerID).
+		Where("activities.id = ?", id).
+		Scan(&row)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &row, nil
+}
+
+func (r *ActivityRepository) FilterViewers(activityID string, viewerIDs []string) ([]string, error) {
+	var ids []string
+	if len(viewerIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.
+		Table("users AS viewers").
+		Joins("JOIN activities ON activities.id = ?", activityID).
+		Joins("LEFT JOIN progresses ON progresses.id = activities.progress_id").
+		Where("viewers.id IN ?", viewerIDs).
+		Where(`(progresses.id IS NULL
+			OR progresses.visibility = ?
+			OR progresses.user_id = viewers.id
+			OR (progresses.visibility = ? AND EXISTS (
+				SELECT 1
+				FROM subscriptions
+				WHERE subscriptions.follower_id = viewers.id
+				  AND subscriptions.following_id = progresses.user_id
+				  AND subscriptions.status = ?
+			)))`, models.ProfileVisibilityPublic, models.ProfileVisibilityFollowers, models.SubscriptionStatusAccepted).
+		Where(`NOT EXISTS (
+			SELECT 1
+			FROM user_blocks
+			WHERE (user_blocks.blocker_id = viewers.id AND user_blocks.blocked_id = activities.user_id)
+			   OR (user_blocks.blocker_id = activities.user_id AND user_blocks.blocked_id = viewers.id)
+		)`).
+		Pluck("viewers.id", &ids).Error
+	return ids, err
+}
+
+func (r *ActivityRepository) activityViewQuery(viewerID string) *gorm.DB {
+	condition, args := entryVisibleCondition("progresses.user_id", "progresses.visibility", viewerID)
+	query := r.db.
 		Table("activities").
 		Select(`
 			activities.id,
@@ -183,10 +263,31 @@ func (r *ActivityRepository) activityViewQuery() *gorm.DB {
 				NULLIF(library_games.capsule_image, ''),
 				NULLIF(library_games.header_image, ''),
 				NULLIF(library_games.background_image, '')
-			) AS steam_icon_url
+			) AS steam_icon_url,
+			likes.likes_count,
+			likes.liked_by_viewer,
+			comments.comments_count
 		`).
 		Joins("JOIN users ON users.id = activities.user_id").
 		Joins("LEFT JOIN users AS target_users ON target_users.id = activities.target_user_id").
 		Joins("LEFT JOIN progresses ON progresses.id = activities.progress_id").
-		Joins("LEFT JOIN library_games ON library_games.steam_app_id = progresses.steam_app_id")
+		Joins("LEFT JOIN library_games ON library_games.id = progresses.library_game_id").
+		Joins(`LEFT JOIN LATERAL (
+			SELECT
+				COUNT(*) AS likes_count,
+				COALESCE(BOOL_OR(activity_likes.user_id::text = ?), FALSE) AS liked_by_viewer
+			FROM activity_likes
+			WHERE activity_likes.activity_id = activities.id
+		) AS likes ON TRUE`, viewerID).
+		Joins(`LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS comments_count
+			FROM activity_comments
+			WHERE activity_comments.activity_id = activities.id
+		) AS comments ON TRUE`).
+		Where("(progresses.id IS NULL OR "+condition+")", args...)
+	if viewerID != "" {
+		blocked, blockedArgs := notBlockedCondition("activities.user_id", viewerID)
+		query = query.Where(blocked, blockedArgs...)
+	}
+	return query
 }
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BlockRepository struct {
+	db *gorm.DB
+}
+
+type RelatedUserRow struct {
+	UserID      string    `gorm:"column:user_id"`
+	DisplayName string    `gorm:"column:display_name"`
+	AvatarURL   string    `gorm:"column:avatar_url"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+func NewBlockRepository(db *gorm.DB) *BlockRepository {
+	return &BlockRepository{db: db}
+}
+
+func (r *BlockRepository) Block(blockerID, blockedID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		block := &models.UserBlock{BlockerID: blockerID, BlockedID: blockedID}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(block).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(
+			"(follower_id = ? AND following_id = ?) OR (follower_id = ? AND following_id = ?)",
+			blockerID, blockedID, blockedID, blockerID,
+		).Delete(&models.Subscription{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("muter_id = ? AND muted_id = ?", blockerID, blockedID).Delete(&models.UserMute{}).Error
+	})
+}
+
+func (r *BlockRepository) Unblock(blockerID, blockedID string) error {
+	return r.db.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Delete(&models.UserBlock{}).Error
+}
+
+func (r *BlockRepository) IsBlocked(blockerID, blockedID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.UserBlock{}).
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *BlockRepository) IsBlockedEither(userID, otherID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.UserBlock{}).
+		Where(
+			"(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)",
+			userID, otherID, otherID, userID,
+		).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *BlockRepository) ListBlocked(blockerID string, limit, offset int) ([]RelatedUserRow, error) {
+	var rows []RelatedUserRow
+	err := r.db.
+		Table("user_blocks").
+		Select("users.id AS user_id, users.display_name, users.avatar_url, user_blocks.created_at").
+		Joins("JOIN users ON users.id = user_blocks.blocked_id").
+		Where("user_blocks.blocker_id = ?", blockerID).
+		Order("user_blocks.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *BlockRepository) CountBlocked(blockerID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.UserBlock{}).Where("blocker_id = ?", blockerID).Count(&count).Error
+	return count, err
+}
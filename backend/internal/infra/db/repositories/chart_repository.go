@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"encoding/json"
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type ChartRepository struct {
+	db *gorm.DB
+}
+
+type ChartScoreRow struct {
+	GameID        string   `gorm:"column:game_id"`
+	Score         float64  `gorm:"column:score"`
+	EventCount    int      `gorm:"column:event_count"`
+	AverageRating *float64 `gorm:"column:average_rating"`
+}
+
+type ChartEntryRow struct {
+	models.LibraryGame
+	Rank          int       `gorm:"column:rank"`
+	Score         float64   `gorm:"column:score"`
+	EventCount    int       `gorm:"column:event_count"`
+	AverageRating *float64  `gorm:"column:average_rating"`
+	ComputedAt    time.Time `gorm:"column:computed_at"`
+}
+
+func NewChartRepository(db *gorm.DB) *ChartRepository {
+	return &ChartRepository{db: db}
+}
+
+func (r *ChartRepository) ReplaceChart(chartType models.ChartType, window models.ChartWindow, entries []*models.GameChartEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("chart_type = ? AND time_window = ?", chartType, window).Delete(&models.GameChartEntry{}).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(entries, 500).Error
+	})
+}
+
+func (r *ChartRepository) ComputeTrending(since time.Time) ([]ChartScoreRow, error) {
+	var rows []ChartScoreRow
+	err := r.db.Raw(`
+		SELECT library_games.id AS game_id, COUNT(*) AS score, COUNT(*) AS event_count
+		FROM (
+			SELECT progresses.library_game_id
+			FROM progresses
+			WHERE progresses.created_at >= ?
+			  AND progresses.visibility = ?
+			UNION ALL
+			SELECT progresses.library_game_id
+			FROM activities
+			JOIN progresses ON progresses.id = activities.progress_id
+			WHERE activities.type = ?
+			  AND activities.status = ?
+			  AND activities.created_at >= ?
+			  AND progresses.visibility = ?
+		) AS events
+		JOIN library_games ON library_games.id = events.library_game_id
+		GROUP BY library_games.id
+	`,
+		since,
+		models.ProfileVisibilityPublic,
+		models.ActivityTypeUpdateStatus,
+		models.GameStatusPlaying,
+		since,
+		models.ProfileVisibilityPublic,
+	).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ChartRepository) ComputeMostDropped(since time.Time) ([]ChartScoreRow, error) {
+	var rows []ChartScoreRow
+	err := r.db.Raw(`
+		SELECT library_games.id AS game_id, COUNT(*) AS score, COUNT(*) AS event_count
+		FROM (
+			SELECT progresses.library_game_id
+			FROM progresses
+			WHERE progresses.created_at >= ?
+			  AND progresses.status = ?
+			  AND progresses.visibility = ?
+			UNION ALL
+			SELECT progresses.library_game_id
+			FROM activities
+			JOIN progresses ON progresses.id = activities.progress_id
+			WHERE activities.type = ?
+			  AND activities.status = ?
+			  AND activities.created_at >= ?
+			  AND progresses.visibility = ?
+		) AS events
+		JOIN library_games ON library_games.id = events.library_game_id
+		GROUP BY library_games.id
+	`,
+		since,
+		models.GameStatusDropped,
+		models.ProfileVisibilityPublic,
+		models.ActivityTypeUpdateStatus,
+		models.GameStatusDropped,
+		since,
+		models.ProfileVisibilityPublic,
+	).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ChartRepository) ComputeTopRated(since *time.Time, prior float64) ([]ChartScoreRow, error) {
+	ratings := r.db.
+		Table("progresses").
+		Select("progresses.library_game_id, progresses.rating").
+		Where("progresses.rating IS NOT NULL").
+		Where("progresses.library_game_id IS NOT NULL").
+		Where("progresses.visibility = ?", models.ProfileVisibilityPublic)
+	if since != nil {
+		ratings = ratings.Where("progresses.updated_at >= ?", *since)
+	}
+
+	var rows []ChartScoreRow
+	err := r.db.Raw(`
+		WITH ratings AS (?),
+		mean AS (SELECT COALESCE(AVG(rating), 0) AS value FROM ratings)
+		SELECT
+			library_games.id AS game_id,
+			COUNT(*) AS event_count,
+			AVG(ratings.rating) AS average_rating,
+			(COUNT(*) * AVG(ratings.rating) + ? * (SELECT value FROM mean)) / (COUNT(*) + ?) AS score
+		FROM ratings
+		JOIN library_games ON library_games.id = ratings.library_game_id
+		GROUP BY library_games.id
+	`, ratings, prior, prior).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ChartRepository) ListChart(chartType models.ChartType, window models.ChartWindow, genre string, limit, offset int) ([]ChartEntryRow, error) {
+	var rows []ChartEntryRow
+	err := r.chartQuery(chartType, window, genre).
+		Select(`
+			library_games.*,
+			ROW_NUMBER() OVER (ORDER BY game_chart_entries.score DESC, game_chart_entries.event_count DESC, library_games.name ASC) AS rank,
+			game_chart_entries.score,
+			game_chart_entries.event_count,
+			game_chart_entries.average_rating,
+			game_chart_entries.computed_at
+		`).
+		Order("rank ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ChartRepository) CountChart(chartType models.ChartType, window models.ChartWindow, genre string) (int64, error) {
+	var count int64
+	err := r.chartQuery(chartType, window, genre).Count(&count).Error
+	return count, err
+}
+
+func (r *ChartRepository) GetComputedAt(chartType models.ChartType, window models.ChartWindow) (*time.Time, error) {
+	var computedAt *time.Time
+	err := r.db.
+		Model(&models.GameChartEntry{}).
+		Select("MAX(computed_at)").
+		Where("chart_type = ? AND time_window = ?", chartType, window).
+		Scan(&computedAt).Error
+	return computedAt, err
+}
+
+func (r *ChartRepository) chartQuery(chartType models.ChartType, window models.ChartWindow, genre string) *gorm.DB {
+	query := r.db.
+		Table("game_chart_entries").
+		Joins("JOIN library_games ON library_games.id = game_chart_entries.game_id").
+		Where("game_chart_entries.chart_type = ?", chartType).
+		Where("game_chart_entries.time_window = ?", window).
+		Where("library_games.moderation_status = ?", models.LibraryGameModerationApproved)
+	if genre != "" {
+		payload, _ := json.Marshal([]string{genre})
+		query = query.Where("library_games.genres @> ?::jsonb", string(payload))
+	}
+	return query
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type Cursor struct {
+	CreatedAt time.Time
+	Value     float64
+	ID        string
+}
+
+type cursorPayload struct {
+	CreatedAt int64   `json:"t,omitempty"`
+	Value     float64 `json:"v,omitempty"`
+	ID        string  `json:"id"`
+}
+
+func EncodeCursor(cursor Cursor) string {
+	payload := cursorPayload{
+		Value: cursor.Value,
+		ID:    cursor.ID,
+	}
+	if !cursor.CreatedAt.IsZero() {
+		payload.CreatedAt = cursor.CreatedAt.UnixMicro()
+	}
+	data, _ := json.Marshal(payload)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &Cursor{
+		Value: payload.Value,
+		ID:    payload.ID,
+	}
+	if payload.CreatedAt != 0 {
+		cursor.CreatedAt = time.UnixMicro(payload.CreatedAt)
+	}
+	return cursor, nil
+}
+
+func paginateByCreatedAt(query *gorm.DB, table string, cursor *Cursor, limit, offset int) *gorm.DB {
+	query = query.Order(table + ".created_at DESC, " + table + ".id DESC")
+	if cursor != nil {
+		query = query.Where("("+table+".created_at, "+table+".id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	} else if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	return query
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,6 +17,30 @@ type LibraryRepository struct {
 	db *gorm.DB
 }
 
+type LibraryFacetField string
+
+const (
+	LibraryFacetGenres     LibraryFacetField = "genres"
+	LibraryFacetCategories LibraryFacetField = "categories"
+	LibraryFacetTags       LibraryFacetField = "tags"
+)
+
+type LibraryFilter struct {
+	Search     string
+	Genre      string
+	Genres     []string
+	Categories []string
+	Tags       []string
+	MatchAny   bool
+	MinRating  float64
+	MinRatings int
+}
+
+type LibraryFacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
 type LibraryGameRow struct {
 	models.LibraryGame
 	AverageRating float64 `json:"averageRating" gorm:"column:average_rating"`
@@ -67,20 +93,94 @@ func (r *LibraryRepository) GetBySteamAppID(appID int) (*models.LibraryGame, err
 	return &game, nil
 }
 
-func (r *LibraryRepository) Count(search, genre string) (int64, error) {
-	var count int64
-	query := r.db.Model(&models.LibraryGame{})
-	if search != "" {
-		query = query.Where("name ILIKE ?", "%"+search+"%")
+func (r *LibraryRepository) ExistsByNameAndPlatform(name, platform string) (bool, error) {
+	var exists bool
+	err := r.db.Raw(
+		`SELECT EXISTS(
+			SELECT 1
+			FROM library_games
+			WHERE LOWER(name) = LOWER(?)
+			  AND LOWER(COALESCE(platform, '')) = LOWER(?)
+			  AND moderation_status <> ?
+		)`,
+		name,
+		platform,
+		models.LibraryGameModerationRejected,
+	).Scan(&exists).Error
+	return exists, err
+}
+
+func (r *LibraryRepository) ListByModerationStatus(status models.LibraryGameModerationStatus, limit, offset int) ([]*models.LibraryGame, error) {
+	var games []*models.LibraryGame
+	query := r.db.Where("source = ?", models.LibraryGameSourceUser)
+	if status != "" {
+		query = query.Where("moderation_status = ?", status)
 	}
-	if genre != "" {
-		query = query.Where("genres::text ILIKE ?", "%"+genre+"%")
+	err := query.
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&games).Error
+	return games, err
+}
+
+func (r *LibraryRepository) CountByModerationStatus(status models.LibraryGameModerationStatus) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.LibraryGame{}).Where("source = ?", models.LibraryGameSourceUser)
+	if status != "" {
+		query = query.Where("moderation_status = ?", status)
 	}
 	err := query.Count(&count).Error
 	return count, err
 }
 
-func (r *LibraryRepository) ListWithStats(limit, offset int, search, genre, sortBy, order string) ([]LibraryGameRow, error) {
+func (r *LibraryRepository) UpdateModeration(id string, status models.LibraryGameModerationStatus, note string, moderatedAt time.Time) error {
+	result := r.db.Model(&models.LibraryGame{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"moderation_status": status,
+			"moderation_note":   note,
+			"moderated_at":      moderatedAt,
+			"updated_at":        moderatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *LibraryRepository) AttachSteamApp(game *models.LibraryGame) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(game).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Progress{}).
+			Where("library_game_id = ?", game.ID).
+			Update("steam_app_id", game.SteamAppID).Error
+	})
+}
+
+func (r *LibraryRepository) ListFeatures() ([]*models.LibraryGame, error) {
+	var games []*models.LibraryGame
+	err := r.db.
+		Select("id", "steam_app_id", "name", "genres", "categories", "tags").
+		Where("moderation_status = ?", models.LibraryGameModerationApproved).
+		Find(&games).Error
+	return games, err
+}
+
+func (r *LibraryRepository) Count(filter LibraryFilter) (int64, error) {
+	var count int64
+	err := r.db.
+		Table("(?) AS filtered_games", r.filteredStatsQuery(filter)).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *LibraryRepository) ListWithStats(filter LibraryFilter, limit, offset int, sortBy, order string) ([]LibraryGameRow, error) {
 	sortColumn := "library_games.created_at"
 	switch sortBy {
 	case "rating":
@@ -102,39 +202,52 @@ func (r *LibraryRepository) ListWithStats(limit, offset int, search, genre, sort
 		order = "desc"
 	}
 
-	query := r.db.
-		Table("library_games").
-		Select(`
-			library_games.*,
-			COALESCE(AVG(progresses.rating), 0) AS average_rating,
-			COALESCE(COUNT(progresses.rating), 0) AS ratings_count,
-			COALESCE(SUM(CASE WHEN TRIM(COALESCE(progresses.review, '')) <> '' THEN 1 ELSE 0 END), 0) AS reviews_count,
-			COALESCE(COUNT(progresses.id), 0) AS progress_count
-		`).
-		Joins("LEFT JOIN progresses ON progresses.steam_app_id = library_games.steam_app_id").
-		Group("library_games.id").
-		Order(fmt.Sprintf("%s %s", sortColumn, order)).
+	var rows []LibraryGameRow
+	err := r.filteredStatsQuery(filter).
+		Order(fmt.Sprintf("%s %s, library_games.id", sortColumn, order)).
 		Limit(limit).
-		Offset(offset)
-
-	if search != "" {
-		query = query.Where("library_games.name ILIKE ?", "%"+search+"%")
+		Offset(offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
 	}
-	if genre != "" {
-		query = query.Where("library_games.genres::text ILIKE ?", "%"+genre+"%")
+
+	return rows, nil
+}
+
+func (r *LibraryRepository) ListFacets(filter LibraryFilter, field LibraryFacetField, limit int) ([]LibraryFacetCount, error) {
+	column := ""
+	switch field {
+	case LibraryFacetGenres:
+		column = "genres"
+	case LibraryFacetCategories:
+		column = "categories"
+	case LibraryFacetTags:
+		column = "tags"
+	default:
+		return nil, fmt.Errorf("unknown library facet %q", field)
 	}
 
-	var rows []LibraryGameRow
-	if err := query.Scan(&rows).Error; err != nil {
+	ids := r.filteredStatsQuery(filter).Select("library_games.id")
+
+	var rows []LibraryFacetCount
+	err := r.db.
+		Table("library_games").
+		Select("facet.value AS value, COUNT(*) AS count").
+		Joins(fmt.Sprintf("CROSS JOIN LATERAL jsonb_array_elements_text(COALESCE(library_games.%s, '[]'::jsonb)) AS facet(value)", column)).
+		Where("library_games.id IN (?)", ids).
+		Group("facet.value").
+		Order("count DESC, facet.value ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
 		return nil, err
 	}
-
 	return rows, nil
 }
 
-func (r *LibraryRepository) GetWithStatsByID(id string) (*LibraryGameRow, error) {
-	var row LibraryGameRow
-	tx := r.db.
+func (r *LibraryRepository) filteredStatsQuery(filter LibraryFilter) *gorm.DB {
+	query := r.db.
 		Table("library_games").
 		Select(`
 			library_games.*,
@@ -143,21 +256,49 @@ func (r *LibraryRepository) GetWithStatsByID(id string) (*LibraryGameRow, error)
 			COALESCE(SUM(CASE WHEN TRIM(COALESCE(progresses.review, '')) <> '' THEN 1 ELSE 0 END), 0) AS reviews_count,
 			COALESCE(COUNT(progresses.id), 0) AS progress_count
 		`).
-		Joins("LEFT JOIN progresses ON progresses.steam_app_id = library_games.steam_app_id").
-		Where("library_games.id = ?", id).
-		Group("library_games.id").
-		Scan(&row)
+		Joins("LEFT JOIN progresses ON progresses.library_game_id = library_games.id AND progresses.visibility = ?", models.ProfileVisibilityPublic).
+		Where("library_games.moderation_status = ?", models.LibraryGameModerationApproved).
+		Group("library_games.id")
 
-	if tx.Error != nil {
-		return nil, tx.Error
+	if filter.Search != "" {
+		query = query.Where("library_games.name ILIKE ?", "%"+filter.Search+"%")
 	}
-	if tx.RowsAffected == 0 {
-		return nil, gorm.ErrRecordNotFound
+	if filter.Genre != "" {
+		query = query.Where("library_games.genres::text ILIKE ?", "%"+filter.Genre+"%")
 	}
-	return &row, nil
+	query = applyJSONBFacetFilter(query, "library_games.genres", filter.Genres, filter.MatchAny)
+	query = applyJSONBFacetFilter(query, "library_games.categories", filter.Categories, filter.MatchAny)
+	query = applyJSONBFacetFilter(query, "library_games.tags", filter.Tags, filter.MatchAny)
+	if filter.MinRating > 0 {
+		query = query.Having("COALESCE(AVG(progresses.rating), 0) >= ?", filter.MinRating)
+	}
+	if filter.MinRatings > 0 {
+		query = query.Having("COUNT(progresses.rating) >= ?", filter.MinRatings)
+	}
+	return query
+}
+
+func applyJSONBFacetFilter(query *gorm.DB, column string, values []string, matchAny bool) *gorm.DB {
+	if len(values) == 0 {
+		return query
+	}
+
+	if !matchAny {
+		payload, _ := json.Marshal(values)
+		return query.Where(column+" @> ?::jsonb", string(payload))
+	}
+
+	conditions := make([]string, 0, len(values))
+	args := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		payload, _ := json.Marshal([]string{value})
+		conditions = append(conditions, column+" @> ?::jsonb")
+		args = append(args, string(payload))
+	}
+	return query.Where("("+strings.Join(conditions, " OR ")+")", args...)
 }
 
-func (r *LibraryRepository) GetWithStatsBySteamAppID(appID int) (*LibraryGameRow, error) {
+func (r *LibraryRepository) GetWithStatsByID(id string) (*LibraryGameRow, error) {
 	var row LibraryGameRow
 	tx := r.db.
 		Table("library_games").
@@ -168,8 +309,8 @@ func (r *LibraryRepository) GetWithStatsBySteamAppID(appID int) (*LibraryGameRow
 			COALESCE(SUM(CASE WHEN TRIM(COALESCE(progresses.review, '')) <> '' THEN 1 ELSE 0 END), 0) AS reviews_count,
 			COALESCE(COUNT(progresses.id), 0) AS progress_count
 		`).
-		Joins("LEFT JOIN progresses ON progresses.steam_app_id = library_games.steam_app_id").
-		Where("library_games.steam_app_id = ?", appID).
+		Joins("LEFT JOIN progresses ON progresses.library_game_id = library_games.id AND progresses.visibility = ?", models.ProfileVisibilityPublic).
+		Where("library_games.id = ?", id).
 		Group("library_games.id").
 		Scan(&row)
 
@@ -183,44 +324,88 @@ func (r *LibraryRepository) GetWithStatsBySteamAppID(appID int) (*LibraryGameRow
 }
 
 type LibraryComment struct {
-	ID        string    `json:"id"`
-	Review    string    `json:"review"`
-	Rating    *int      `json:"rating,omitempty"`
-	CreatedAt time.Time `json:"createdAt"`
-	User      struct {
+	ID             string    `json:"id"`
+	Review         string    `json:"review"`
+	Rating         *int      `json:"rating,omitempty"`
+	HelpfulCount   int       `json:"helpfulCount"`
+	UnhelpfulCount int       `json:"unhelpfulCount"`
+	ViewerVote     *bool     `json:"viewerVote,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	User           struct {
 		ID          string `json:"id"`
 		DisplayName string `json:"displayName"`
 		AvatarURL   string `json:"avatarUrl"`
 	} `json:"user"`
 }
 
-func (r *LibraryRepository) GetCommentsBySteamAppID(appID int, limit, offset int) ([]LibraryComment, error) {
+func (r *LibraryRepository) GetCommentsByGameID(gameID string, viewerID, sortBy string, limit, offset int) ([]LibraryComment, error) {
 	type commentRow struct {
-		ID          string    `gorm:"column:id"`
-		Review      string    `gorm:"column:review"`
-		Rating      *int      `gorm:"column:rating"`
-		CreatedAt   time.Time `gorm:"column:created_at"`
-		UserID      string    `gorm:"column:user_id"`
-		DisplayName string    `gorm:"column:display_name"`
-		AvatarURL   string    `gorm:"column:avatar_url"`
+		ID             string    `gorm:"column:id"`
+		Review         string    `gorm:"column:review"`
+		Rating         *int      `gorm:"column:rating"`
+		HelpfulCount   int       `gorm:"column:helpful_count"`
+		UnhelpfulCount int       `gorm:"column:unhelpful_count"`
+		ViewerHelpful  *bool     `gorm:"column:viewer_helpful"`
+		CreatedAt      time.Time `gorm:"column:created_at"`
+		UserID         string    `gorm:"column:user_id"`
+		DisplayName    string    `gorm:"column:display_name"`
+		AvatarURL      string    `gorm:"column:avatar_url"`
 	}
 
-	var rows []commentRow
-	err := r.db.
+	viewerColumn := "NULL::boolean AS viewer_helpful"
+	if viewerID != "" {
+		viewerColumn = "viewer_votes.helpful AS viewer_helpful"
+	}
+
+	query := r.db.
 		Table("progresses").
 		Select(`
 			progresses.id,
 			progresses.review,
 			progresses.rating,
 			progresses.created_at,
+			COALESCE(votes.helpful_count, 0) AS helpful_count,
+			COALESCE(votes.unhelpful_count, 0) AS unhelpful_count,
+			`+viewerColumn+`,
 			users.id AS user_id,
 			users.display_name,
 			users.avatar_url
 		`).
 		Joins("JOIN users ON users.id = progresses.user_id").
-		Where("progresses.steam_app_id = ?", appID).
+		Joins(`LEFT JOIN (
+			SELECT
+				progress_id,
+				SUM(CASE WHEN helpful THEN 1 ELSE 0 END) AS helpful_count,
+				SUM(CASE WHEN helpful THEN 0 ELSE 1 END) AS unhelpful_count
+			FROM review_votes
+			WHERE progress_id IN (SELECT id FROM progresses WHERE library_game_id = ?)
+			GROUP BY progress_id
+		) AS votes ON votes.progress_id = progresses.id`, gameID)
+	if viewerID != "" {
+		query = query.Joins("LEFT JOIN review_votes AS viewer_votes ON viewer_votes.progress_id = progresses.id AND viewer_votes.user_id = ?", viewerID)
+	}
+
+	switch sortBy {
+	case "helpful":
+		query = query.Order("COALESCE(votes.helpful_count, 0) - COALESCE(votes.unhelpful_count, 0) DESC").
+			Order("COALESCE(votes.helpful_count, 0) DESC").
+			Order("progresses.created_at DESC")
+	default:
+		query = query.Order("progresses.created_at DESC")
+	}
+
+	visibility, visibilityArgs := profileVisibleCondition("users.id", "users.profile_visibility", viewerID)
+	if viewerID != "" {
+		blocked, blockedArgs := notBlockedCondition("users.id", viewerID)
+		query = query.Where(blocked, blockedArgs...)
+	}
+
+	var rows []commentRow
+	err := query.
+		Where("progresses.library_game_id = ?", gameID).
+		Where("progresses.visibility = ?", models.ProfileVisibilityPublic).
+		Where(visibility, visibilityArgs...).
 		Where("TRIM(COALESCE(progresses.review, '')) <> ''").
-		Order("progresses.created_at DESC").
 		Limit(limit).
 		Offset(offset).
 		Scan(&rows).Error
@@ -231,10 +416,13 @@ func (r *LibraryRepository) GetCommentsBySteamAppID(appID int, limit, offset int
 	comments := make([]LibraryComment, 0, len(rows))
 	for _, row := range rows {
 		comment := LibraryComment{
-			ID:        row.ID,
-			Review:    row.Review,
-			Rating:    row.Rating,
-			CreatedAt: row.CreatedAt,
+			ID:             row.ID,
+			Review:         row.Review,
+			Rating:         row.Rating,
+			HelpfulCount:   row.HelpfulCount,
+			UnhelpfulCount: row.UnhelpfulCount,
+			ViewerVote:     row.ViewerHelpful,
+			CreatedAt:      row.CreatedAt,
 		}
 		comment.User.ID = row.UserID
 		comment.User.DisplayName = row.DisplayName
@@ -244,3 +432,303 @@ func (r *LibraryRepository) GetCommentsBySteamAppID(appID int, limit, offset int
 
 	return comments, nil
 }
+
+type LibraryGameStatsRow struct {
+	ProgressCount           int64    `gorm:"column:progress_count"`
+	RatingsCount            int64    `gorm:"column:ratings_count"`
+	AverageRating           float64  `gorm:"column:average_rating"`
+	PlanToPlayCount         int64    `gorm:"column:plan_to_play_count"`
+	PlayingCount            int64    `gorm:"column:playing_count"`
+	CompletedCount          int64    `gorm:"column:completed_count"`
+	DroppedCount            int64    `gorm:"column:dropped_count"`
+	MedianCompletedPlaytime *float64 `gorm:"column:median_completed_playtime"`
+	RatingHistogram         string   `gorm:"column:rating_histogram"`
+	FollowingRatings        string   `gorm:"column:following_ratings"`
+}
+
+type FollowingRatingRow struct {
+	UserID      string            `json:"userId"`
+	DisplayName string            `json:"displayName"`
+	AvatarURL   string            `json:"avatarUrl"`
+	Rating      int               `json:"rating"`
+	Status      models.GameStatus `json:"status"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+func (r *LibraryRepository) GetGameStats(gameID string, viewerID string) (*LibraryGameStatsRow, error) {
+	histogram := make([]string, 0, 10)
+	for rating := 1; rating <= 10; rating++ {
+		histogram = append(histogram, fmt.Sprintf("COUNT(*) FILTER (WHERE progresses.visibility = @public AND progresses.rating = %d)", rating))
+	}
+
+	followingJoin := "LEFT JOIN subscriptions AS followed ON FALSE"
+	if viewerID != "" {
+		followingJoin = "LEFT JOIN subscriptions AS followed ON followed.follower_id = @viewer AND followed.following_id = progresses.user_id AND followed.status = @accepted"
+	}
+
+	visibility, visibilityArgs := entryVisibleCondition("progresses.user_id", "progresses.visibility", viewerID)
+
+	var row LibraryGameStatsRow
+	err := r.db.
+		Table("progresses").
+		Select(`
+			COUNT(*) FILTER (WHERE progresses.visibility = @public) AS progress_count,
+			COUNT(progresses.rating) FILTER (WHERE progresses.visibility = @public) AS ratings_count,
+			COALESCE(AVG(progresses.rating) FILTER (WHERE progresses.visibility = @public), 0) AS average_rating,
+			COUNT(*) FILTER (WHERE progresses.visibility = @public AND progresses.status = @plan_to_play) AS plan_to_play_count,
+			COUNT(*) FILTER (WHERE progresses.visibility = @public AND progresses.status = @playing) AS playing_count,
+			COUNT(*) FILTER (WHERE progresses.visibility = @public AND progresses.status = @completed) AS completed_count,
+			COUNT(*) FILTER (WHERE progresses.visibility = @public AND progresses.status = @dropped) AS dropped_count,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY progresses.steam_playtime_forever) FILTER (WHERE progresses.visibility = @public AND progresses.status = @completed AND progresses.steam_playtime_forever > 0) AS median_completed_playtime,
+			jsonb_build_array(`+strings.Join(histogram, ", ")+`)::text AS rating_histogram,
+			COALESCE(jsonb_agg(jsonb_build_object(
+				'userId', users.id,
+				'displayName', users.display_name,
+				'avatarUrl', users.avatar_url,
+				'rating', progresses.rating,
+				'status', progresses.status,
+				'updatedAt', progresses.updated_at
+			) ORDER BY progresses.updated_at DESC) FILTER (WHERE followed.following_id IS NOT NULL AND progresses.rating IS NOT NULL), '[]'::jsonb)::text AS following_ratings
+		`, map[string]interface{}{
+			"public":       models.ProfileVisibilityPublic,
+			"plan_to_play": models.GameStatusPlanToPlay,
+			"playing":      models.GameStatusPlaying,
+			"completed":    models.GameStatusCompleted,
+			"dropped":      models.GameStatusDropped,
+		}).
+		Joins("JOIN users ON users.id = progresses.user_id").
+		Joins(followingJoin, map[string]interface{}{
+			"viewer":   viewerID,
+			"accepted": models.SubscriptionStatusAccepted,
+		}).
+		Where("progresses.library_game_id = ?", gameID).
+		Where(visibility, visibilityArgs...).
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+const libraryNameKeySQL = `regexp_replace(
+	regexp_replace(
+		lower(library_games.name),
+		'(\s*[-:–(]{0,1}\s*(demo|playtest|prologue|soundtrack|original soundtrack|ost|remastered|remaster|definitive edition|game of the year edition|goty edition|goty|complete edition|deluxe edition|enhanced edition|anniversary edition|director''s cut)\){0,1}\s*)+$',
+		'',
+		'g'
+	),
+	'[^[:alnum:]]+',
+	'',
+	'g'
+)`
+
+type LibraryDuplicateGroupRow struct {
+	NameKey   string `gorm:"column:name_key"`
+	GameCount int64  `gorm:"column:game_count"`
+	GameIDs   string `gorm:"column:game_ids"`
+}
+
+type LibraryDuplicateGameRow struct {
+	models.LibraryGame
+	EntryCount int64 `json:"entryCount" gorm:"column:entry_count"`
+}
+
+func (r *LibraryRepository) GetByIDWithRedirect(id string) (*models.LibraryGame, error) {
+	game, err := r.GetByID(id)
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return game, err
+	}
+
+	var redirect models.LibraryGameRedirect
+	if err := r.db.First(&redirect, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(redirect.TargetID)
+}
+
+func (r *LibraryRepository) GetBySteamAppIDWithRedirect(appID int) (*models.LibraryGame, error) {
+	game, err := r.GetBySteamAppID(appID)
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return game, err
+	}
+
+	var redirect models.LibraryGameRedirect
+	if err := r.db.First(&redirect, "steam_app_id = ?", appID).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(redirect.TargetID)
+}
+
+func (r *LibraryRepository) duplicateGroupsQuery() *gorm.DB {
+	normalized := r.db.
+		Table("library_games").
+		Select("library_games.id, "+libraryNameKeySQL+" AS name_key").
+		Where("library_games.moderation_status <> ?", models.LibraryGameModerationRejected)
+
+	return r.db.
+		Table("(?) AS normalized", normalized).
+		Select("normalized.name_key, COUNT(*) AS game_count, json_agg(normalized.id ORDER BY normalized.id)::text AS game_ids").
+		Where("normalized.name_key <> ''").
+		Group("normalized.name_key").
+		Having("COUNT(*) > 1")
+}
+
+func (r *LibraryRepository) ListDuplicateGroups(limit, offset int) ([]LibraryDuplicateGroupRow, error) {
+	var rows []LibraryDuplicateGroupRow
+	err := r.duplicateGroupsQuery().
+		Order("game_count DESC, normalized.name_key ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *LibraryRepository) CountDuplicateGroups() (int64, error) {
+	var count int64
+	err := r.db.Table("(?) AS duplicate_groups", r.duplicateGroupsQuery()).Count(&count).Error
+	return count, err
+}
+
+func (r *LibraryRepository) ListWithEntryCountsByIDs(ids []string) ([]LibraryDuplicateGameRow, error) {
+	var rows []LibraryDuplicateGameRow
+	if len(ids) == 0 {
+		return rows, nil
+	}
+	err := r.db.
+		Table("library_games").
+		Select("library_games.*, (SELECT COUNT(*) FROM progresses WHERE progresses.library_game_id = library_games.id) AS entry_count").
+		Where("library_games.id IN ?", ids).
+		Order("entry_count DESC, library_games.created_at ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *LibraryRepository) MergeInto(target *models.LibraryGame, sources []*models.LibraryGame) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, source := range sources {
+			if err := mergeLibraryGame(tx, target, source); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func mergeLibraryGame(tx *gorm.DB, target, source *models.LibraryGame) error {
+	params := map[string]interface{}{
+		"target":    target.ID,
+		"source":    source.ID,
+		"private":   models.ProfileVisibilityPrivate,
+		"followers": models.ProfileVisibilityFollowers,
+	}
+
+	if err := tx.Exec(`
+		UPDATE progresses AS kept
+		SET status = CASE WHEN dup.updated_at > kept.updated_at THEN dup.status ELSE kept.status END,
+			rating = COALESCE(kept.rating, dup.rating),
+			review = CASE WHEN TRIM(COALESCE(kept.review, '')) = '' THEN dup.review ELSE kept.review END,
+			steam_playtime_forever = GREATEST(kept.steam_playtime_forever, dup.steam_playtime_forever),
+			platform = COALESCE(NULLIF(kept.platform, ''), dup.platform),
+			ownership = COALESCE(NULLIF(kept.ownership, ''), dup.ownership),
+			store = COALESCE(NULLIF(kept.store, ''), dup.store),
+			visibility = CASE
+				WHEN @private IN (kept.visibility, dup.visibility) THEN @private
+				WHEN @followers IN (kept.visibility, dup.visibility) THEN @followers
+				ELSE kept.visibility
+			END,
+			updated_at = GREATEST(kept.updated_at, dup.updated_at)
+		FROM progresses AS dup
+		WHERE kept.library_game_id = @target
+		  AND dup.library_game_id = @source
+		  AND dup.user_id = kept.user_id
+	`, params).Error; err != nil {
+		return err
+	}
+
+	for _, table := range []string{"activities", "archived_activities"} {
+		if err := tx.Exec(`
+			UPDATE `+table+`
+			SET progress_id = kept.id
+			FROM progresses AS dup
+			JOIN progresses AS kept ON kept.user_id = dup.user_id AND kept.library_game_id = @target
+			WHERE dup.library_game_id = @source
+			  AND `+table+`.progress_id = dup.id
+		`, params).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, pair := range [][2]string{{"review_votes", "user_id"}, {"review_reports", "reporter_id"}} {
+		table, voter := pair[0], pair[1]
+		if err := tx.Exec(`
+			UPDATE `+table+`
+			SET progress_id = kept.id
+			FROM progresses AS dup
+			JOIN progresses AS kept ON kept.user_id = dup.user_id AND kept.library_game_id = @target
+			WHERE dup.library_game_id = @source
+			  AND `+table+`.progress_id = dup.id
+			  AND kept.review IS NOT DISTINCT FROM dup.review
+			  AND NOT EXISTS (
+				SELECT 1 FROM `+table+` AS existing
+				WHERE existing.progress_id = kept.id
+				  AND existing.`+voter+` = `+table+`.`+voter+`
+			  )
+		`, params).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec(`
+		DELETE FROM progresses AS dup
+		USING progresses AS kept
+		WHERE dup.library_game_id = @source
+		  AND kept.library_game_id = @target
+		  AND kept.user_id = dup.user_id
+	`, params).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`
+		UPDATE progresses
+		SET library_game_id = @target,
+			steam_app_id = COALESCE(@app, progresses.steam_app_id),
+			updated_at = NOW()
+		WHERE library_game_id = @source
+	`, map[string]interface{}{
+		"target": target.ID,
+		"source": source.ID,
+		"app":    target.SteamAppID,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`
+		UPDATE game_series_entries
+		SET game_id = @target
+		WHERE game_id = @source
+		  AND NOT EXISTS (
+			SELECT 1 FROM game_series_entries AS existing
+			WHERE existing.series_id = game_series_entries.series_id
+			  AND existing.game_id = @target
+		  )
+	`, params).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.LibraryGameRedirect{}).
+		Where("target_id = ?", source.ID).
+		Update("target_id", target.ID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Delete(&models.LibraryGame{}, "id = ?", source.ID).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&models.LibraryGameRedirect{
+		ID:         source.ID,
+		SteamAppID: source.SteamAppID,
+		TargetID:   target.ID,
+		CreatedAt:  time.Now(),
+	}).Error
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MuteRepository struct {
+	db *gorm.DB
+}
+
+func NewMuteRepository(db *gorm.DB) *MuteRepository {
+	return &MuteRepository{db: db}
+}
+
+func (r *MuteRepository) Mute(muterID, mutedID string) error {
+	mute := &models.UserMute{MuterID: muterID, MutedID: mutedID}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(mute).Error
+}
+
+func (r *MuteRepository) Unmute(muterID, mutedID string) error {
+	return r.db.Where("muter_id = ? AND muted_id = ?", muterID, mutedID).Delete(&models.UserMute{}).Error
+}
+
+func (r *MuteRepository) IsMuted(muterID, mutedID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.UserMute{}).
+		Where("muter_id = ? AND muted_id = ?", muterID, mutedID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *MuteRepository) ListMuted(muterID string, limit, offset int) ([]RelatedUserRow, error) {
+	var rows []RelatedUserRow
+	err := r.db.
+		Table("user_mutes").
+		Select("users.id AS user_id, users.display_name, users.avatar_url, user_mutes.created_at").
+		Joins("JOIN users ON users.id = user_mutes.muted_id").
+		Where("user_mutes.muter_id = ?", muterID).
+		Order("user_mutes.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *MuteRepository) CountMuted(muterID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.UserMute{}).Where("muter_id = ?", muterID).Count(&count).Error
+	return count, err
+}
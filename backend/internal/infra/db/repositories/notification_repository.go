@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+type NotificationRow struct {
+	ID               string                  `gorm:"column:id"`
+	UserID           string                  `gorm:"column:user_id"`
+	Type             models.NotificationType `gorm:"column:type"`
+	ActorID          *string                 `gorm:"column:actor_id"`
+	ActorDisplayName *string                 `gorm:"column:actor_display_name"`
+	ActorAvatarURL   *string                 `gorm:"column:actor_avatar_url"`
+	ActivityID       *string                 `gorm:"column:activity_id"`
+	CommentID        *string                 `gorm:"column:comment_id"`
+	GameName         *string                 `gorm:"column:game_name"`
+	ReadAt           *time.Time              `gorm:"column:read_at"`
+	CreatedAt        time.Time               `gorm:"column:created_at"`
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *NotificationRepository) CreateForFollowers(notification *models.Notification) error {
+	if notification.ActorID == nil {
+		return nil
+	}
+
+	return r.db.Exec(
+		`INSERT INTO notifications (id, user_id, actor_id, type, activity_id, comment_id, game_name, created_at)
+		SELECT gen_random_uuid(), subscriptions.follower_id, ?, ?, ?, ?, ?, NOW()
+		FROM subscriptions
+		WHERE subscriptions.following_id = ?
+		  AND subscriptions.status = 'accepted'
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM user_mutes
+			WHERE user_mutes.muter_id = subscriptions.follower_id
+			  AND user_mutes.muted_id = ?
+		  )
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM notification_preferences
+			WHERE notification_preferences.user_id = subscriptions.follower_id
+			  AND notification_preferences.type = ?
+			  AND notification_preferences.enabled = FALSE
+		  )`,
+		*notification.ActorID,
+		notification.Type,
+		notification.ActivityID,
+		notification.CommentID,
+		notification.GameName,
+		*notification.ActorID,
+		*notification.ActorID,
+		notification.Type,
+	).Error
+}
+
+func (r *NotificationRepository) ExistsUnread(userID, actorID string, notificationType models.NotificationType, activityID string) (bool, error) {
+	var exists bool
+	err := r.db.Raw(
+		`SELECT EXISTS(
+			SELECT 1
+			FROM notifications
+			WHERE user_id = ?
+			  AND actor_id = ?
+			  AND type = ?
+			  AND activity_id = ?
+			  AND read_at IS NULL
+		)`,
+		userID,
+		actorID,
+		notificationType,
+		activityID,
+	).Scan(&exists).Error
+	return exists, err
+}
+
+func (r *NotificationRepository) ListByUserID(userID string, unreadOnly bool, limit, offset int) ([]NotificationRow, error) {
+	var rows []NotificationRow
+	query := r.notificationViewQuery().
+		Where("notifications.user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("notifications.read_at IS NULL")
+	}
+	err := query.
+		Order("notifications.created_at DESC, notifications.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *NotificationRepository) ListSince(userID string, since Cursor, limit int) ([]NotificationRow, error) {
+	var rows []NotificationRow
+	err := r.notificationViewQuery().
+		Where("notifications.user_id = ?", userID).
+		Where("(notifications.created_at, notifications.id) > (?, ?)", since.CreatedAt, since.ID).
+		Order("notifications.created_at ASC, notifications.id ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *NotificationRepository) GetRowByID(id string) (*NotificationRow, error) {
+	var row NotificationRow
+	tx := r.notificationViewQuery().
+		Where("notifications.id = ?", id).
+		Scan(&row)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &row, nil
+}
+
+func (r *NotificationRepository) CountByUserID(userID string, unreadOnly bool) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+func (r *NotificationRepository) MarkRead(id, userID string) (int64, error) {
+	tx := r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", time.Now())
+	return tx.RowsAffected, tx.Error
+}
+
+func (r *NotificationRepository) MarkAllRead(userID string) error {
+	return r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
+
+func (r *NotificationRepository) Exists(id, userID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *NotificationRepository) GetPreferences(userID string) ([]models.NotificationPreference, error) {
+	var prefs []models.NotificationPreference
+	err := r.db.Where("user_id = ?", userID).Find(&prefs).Error
+	return prefs, err
+}
+
+func (r *NotificationRepository) IsEnabled(userID string, notificationType models.NotificationType) (bool, error) {
+	var prefs []models.NotificationPreference
+	if err := r.db.
+		Where("user_id = ? AND type = ?", userID, notificationType).
+		Limit(1).
+		Find(&prefs).Error; err != nil {
+		return false, err
+	}
+	if len(prefs) == 0 {
+		return true, nil
+	}
+	return prefs[0].Enabled, nil
+}
+
+func (r *NotificationRepository) UpsertPreference(pref *models.NotificationPreference) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(pref).Error
+}
+
+func (r *NotificationRepository) notificationViewQuery() *gorm.DB {
+	return r.db.
+		Table("notifications").
+		Select(`
+			notifications.id,
+			notifications.user_id,
+			notifications.type,
+			notifications.actor_id,
+			notifications.activity_id,
+			notifications.comment_id,
+			notifications.game_name,
+			notifications.read_at,
+			notifications.created_at,
+			actors.display_name AS actor_display_name,
+			actors.avatar_url AS actor_avatar_url
+		`).
+		Joins("LEFT JOIN users AS actors ON actors.id = notifications.actor_id")
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type ProgressImportRepository struct {
+	db *gorm.DB
+}
+
+func NewProgressImportRepository(db *gorm.DB) *ProgressImportRepository {
+	return &ProgressImportRepository{db: db}
+}
+
+func (r *ProgressImportRepository) Create(job *models.ProgressImportJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *ProgressImportRepository) Update(job *models.ProgressImportJob) error {
+	return r.db.Save(job).Error
+}
+
+func (r *ProgressImportRepository) GetByID(id string) (*models.ProgressImportJob, error) {
+	var job models.ProgressImportJob
+	if err := r.db.First(&job, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *ProgressImportRepository) GetByIDAndUserID(id, userID string) (*models.ProgressImportJob, error) {
+	var job models.ProgressImportJob
+	if err := r.db.First(&job, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *ProgressImportRepository) ClaimNext(staleBefore time.Time) (string, error) {
+	var ids []string
+	err := r.db.Raw(
+		`UPDATE progress_import_jobs
+		SET status = ?, updated_at = NOW()
+		WHERE id = (
+			SELECT id
+			FROM progress_import_jobs
+			WHERE status = ?
+			   OR (status = ? AND updated_at < ?)
+			ORDER BY created_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		models.ProgressImportRunning,
+		models.ProgressImportPending,
+		models.ProgressImportRunning,
+		staleBefore,
+	).Scan(&ids).Error
+	if err != nil || len(ids) == 0 {
+		return "", err
+	}
+	return ids[0], nil
+}
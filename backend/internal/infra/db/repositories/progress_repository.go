@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"errors"
 	"time"
 
 	"gamecheck/internal/domain/models"
@@ -17,21 +18,44 @@ type ProgressStats struct {
 	AvgRating   float64
 	RatingCount int64
 	ByStatus    map[string]int64
+	ByPlatform  map[string]int64
+	ByOwnership map[string]int64
+}
+
+type ProgressFilter struct {
+	Status    string
+	Platform  string
+	Ownership string
+	Store     string
 }
 
 type ProgressRow struct {
-	ID                   string            `gorm:"column:id"`
-	UserID               string            `gorm:"column:user_id"`
-	Name                 string            `gorm:"column:name"`
-	Status               models.GameStatus `gorm:"column:status"`
-	Rating               *int              `gorm:"column:rating"`
-	Review               string            `gorm:"column:review"`
-	SteamAppID           *int              `gorm:"column:steam_app_id"`
-	SteamIconURL         string            `gorm:"column:steam_icon_url"`
-	SteamStoreURL        string            `gorm:"column:steam_store_url"`
-	SteamPlaytimeForever *int              `gorm:"column:steam_playtime_forever"`
-	CreatedAt            time.Time         `gorm:"column:created_at"`
-	UpdatedAt            time.Time         `gorm:"column:updated_at"`
+	ID                   string                   `gorm:"column:id"`
+	UserID               string                   `gorm:"column:user_id"`
+	Name                 string                   `gorm:"column:name"`
+	Status               models.GameStatus        `gorm:"column:status"`
+	Rating               *int                     `gorm:"column:rating"`
+	Review               string                   `gorm:"column:review"`
+	SteamAppID           *int                     `gorm:"column:steam_app_id"`
+	LibraryGameID        *string                  `gorm:"column:library_game_id"`
+	SteamIconURL         string                   `gorm:"column:steam_icon_url"`
+	SteamStoreURL        string                   `gorm:"column:steam_store_url"`
+	SteamPlaytimeForever *int                     `gorm:"column:steam_playtime_forever"`
+	Platform             string                   `gorm:"column:platform"`
+	Ownership            models.OwnershipType     `gorm:"column:ownership"`
+	Store                string                   `gorm:"column:store"`
+	Visibility           models.ProfileVisibility `gorm:"column:visibility"`
+	CreatedAt            time.Time                `gorm:"column:created_at"`
+	UpdatedAt            time.Time                `gorm:"column:updated_at"`
+}
+
+type SharedGameRow struct {
+	SteamAppID  int               `gorm:"column:steam_app_id"`
+	Name        string            `gorm:"column:name"`
+	UserStatus  models.GameStatus `gorm:"column:user_status"`
+	UserRating  *int              `gorm:"column:user_rating"`
+	OtherStatus models.GameStatus `gorm:"column:other_status"`
+	OtherRating *int              `gorm:"column:other_rating"`
 }
 
 func NewProgressRepository(db *gorm.DB) *ProgressRepository {
@@ -70,6 +94,37 @@ func (r *ProgressRepository) GetByUserIDAndName(userID, name string) (*models.Pr
 	return &progress, err
 }
 
+func (r *ProgressRepository) FindByUserIDAndGame(userID string, libraryGameID *string, steamAppID *int, name string) (*models.Progress, error) {
+	var progress models.Progress
+	if libraryGameID != nil {
+		err := r.db.Where("user_id = ? AND library_game_id = ?", userID, *libraryGameID).Order("created_at ASC").First(&progress).Error
+		if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+			return &progress, err
+		}
+	}
+	if steamAppID != nil {
+		err := r.db.Where("user_id = ? AND steam_app_id = ?", userID, *steamAppID).Order("created_at ASC").First(&progress).Error
+		if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+			return &progress, err
+		}
+	}
+	if name == "" {
+		return nil, gorm.ErrRecordNotFound
+	}
+	err := r.db.
+		Table("progresses").
+		Select("progresses.*").
+		Joins("LEFT JOIN library_games ON library_games.id = progresses.library_game_id").
+		Where("progresses.user_id = ?", userID).
+		Where("LOWER(COALESCE(NULLIF(library_games.name, ''), progresses.name)) = LOWER(?)", name).
+		Order("progresses.created_at ASC").
+		Take(&progress).Error
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
 func (r *ProgressRepository) ExistsByUserIDAndSteamAppID(userID string, appID int) (bool, error) {
 	var exists bool
 	err := r.db.Raw(
@@ -82,13 +137,25 @@ func (r *ProgressRepository) ExistsByUserIDAndSteamAppID(userID string, appID in
 	return exists, err
 }
 
+func (r *ProgressRepository) ExistsByUserIDAndLibraryGameID(userID, libraryGameID string) (bool, error) {
+	var exists bool
+	err := r.db.Raw(
+		`SELECT EXISTS(
+			SELECT 1 FROM progresses WHERE user_id = ? AND library_game_id = ?
+		)`,
+		userID,
+		libraryGameID,
+	).Scan(&exists).Error
+	return exists, err
+}
+
 func (r *ProgressRepository) ExistsByUserIDAndName(userID, name string) (bool, error) {
 	var exists bool
 	err := r.db.Raw(
 		`SELECT EXISTS(
 			SELECT 1
 			FROM progresses p
-			LEFT JOIN library_games lg ON lg.steam_app_id = p.steam_app_id
+			LEFT JOIN library_games lg ON lg.id = p.library_game_id
 			WHERE p.user_id = ?
 			  AND LOWER(COALESCE(NULLIF(lg.name, ''), p.name)) = LOWER(?)
 		)`,
@@ -98,20 +165,13 @@ func (r *ProgressRepository) ExistsByUserIDAndName(userID, name string) (bool, e
 	return exists, err
 }
 
-func (r *ProgressRepository) ListWithLibraryByUserID(userID, status string, limit, offset int) ([]ProgressRow, error) {
+func (r *ProgressRepository) ListWithLibraryByUserID(userID, viewerID string, filter ProgressFilter, cursor *Cursor, limit, offset int) ([]ProgressRow, error) {
 	var rows []ProgressRow
-	query := r.progressWithLibraryQuery().
-		Where("progresses.user_id = ?", userID)
-	if status != "" {
-		query = query.Where("progresses.status = ?", status)
-	}
-	if limit > 0 {
-		query = query.Limit(limit)
-	}
-	if offset > 0 {
-		query = query.Offset(offset)
-	}
-	err := query.Order("progresses.created_at DESC").Scan(&rows).Error
+	condition, args := entryVisibleCondition("progresses.user_id", "progresses.visibility", viewerID)
+	query := applyProgressFilter(r.progressWithLibraryQuery().
+		Where("progresses.user_id = ?", userID).
+		Where(condition, args...), filter)
+	err := paginateByCreatedAt(query, "progresses", cursor, limit, offset).Scan(&rows).Error
 	return rows, err
 }
 
@@ -129,19 +189,36 @@ func (r *ProgressRepository) GetWithLibraryByID(id string) (*ProgressRow, error)
 	return &row, nil
 }
 
-func (r *ProgressRepository) CountByUserID(userID, status string) (int64, error) {
+func (r *ProgressRepository) CountByUserID(userID, viewerID string, filter ProgressFilter) (int64, error) {
 	var count int64
-	query := r.db.Model(&models.Progress{}).Where("user_id = ?", userID)
-	if status != "" {
-		query = query.Where("status = ?", status)
-	}
-	err := query.Count(&count).Error
+	condition, args := entryVisibleCondition("progresses.user_id", "progresses.visibility", viewerID)
+	query := r.db.Model(&models.Progress{}).Where("progresses.user_id = ?", userID).Where(condition, args...)
+	err := applyProgressFilter(query, filter).Count(&count).Error
 	return count, err
 }
 
-func (r *ProgressRepository) GetStatsByUserID(userID string) (*ProgressStats, error) {
+func applyProgressFilter(query *gorm.DB, filter ProgressFilter) *gorm.DB {
+	if filter.Status != "" {
+		query = query.Where("progresses.status = ?", filter.Status)
+	}
+	if filter.Platform != "" {
+		query = query.Where("progresses.platform = ?", filter.Platform)
+	}
+	if filter.Ownership != "" {
+		query = query.Where("progresses.ownership = ?", filter.Ownership)
+	}
+	if filter.Store != "" {
+		query = query.Where("progresses.store = ?", filter.Store)
+	}
+	return query
+}
+
+func (r *ProgressRepository) GetStatsByUserID(userID, viewerID string) (*ProgressStats, error) {
+	condition, args := entryVisibleCondition("progresses.user_id", "progresses.visibility", viewerID)
 	stats := &ProgressStats{
-		ByStatus: make(map[string]int64),
+		ByStatus:    make(map[string]int64),
+		ByPlatform:  make(map[string]int64),
+		ByOwnership: make(map[string]int64),
 	}
 
 	var totals struct {
@@ -151,6 +228,7 @@ func (r *ProgressRepository) GetStatsByUserID(userID string) (*ProgressStats, er
 	}
 	if err := r.db.Model(&models.Progress{}).
 		Where("user_id = ?", userID).
+		Where(condition, args...).
 		Select("COUNT(*) as total, COALESCE(AVG(rating), 0) as avg_rating, COUNT(rating) as rating_count").
 		Row().
 		Scan(&totals.Total, &totals.AvgRating, &totals.RatingCount); err != nil {
@@ -168,6 +246,7 @@ func (r *ProgressRepository) GetStatsByUserID(userID string) (*ProgressStats, er
 	var rows []statusRow
 	if err := r.db.Model(&models.Progress{}).
 		Where("user_id = ?", userID).
+		Where(condition, args...).
 		Select("status, COUNT(*) as count").
 		Group("status").
 		Scan(&rows).Error; err != nil {
@@ -178,9 +257,89 @@ func (r *ProgressRepository) GetStatsByUserID(userID string) (*ProgressStats, er
 		stats.ByStatus[string(row.Status)] = row.Count
 	}
 
+	type groupRow struct {
+		Value string `gorm:"column:value"`
+		Count int64  `gorm:"column:count"`
+	}
+	var platformRows []groupRow
+	if err := r.db.Model(&models.Progress{}).
+		Where("user_id = ?", userID).
+		Where(condition, args...).
+		Where("platform IS NOT NULL AND platform <> ''").
+		Select("platform AS value, COUNT(*) as count").
+		Group("platform").
+		Scan(&platformRows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range platformRows {
+		stats.ByPlatform[row.Value] = row.Count
+	}
+
+	var ownershipRows []groupRow
+	if err := r.db.Model(&models.Progress{}).
+		Where("user_id = ?", userID).
+		Where(condition, args...).
+		Where("ownership IS NOT NULL AND ownership <> ''").
+		Select("ownership AS value, COUNT(*) as count").
+		Group("ownership").
+		Scan(&ownershipRows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range ownershipRows {
+		stats.ByOwnership[row.Value] = row.Count
+	}
+
 	return stats, nil
 }
 
+func (r *ProgressRepository) ListSharedGames(userID, otherID string) ([]SharedGameRow, error) {
+	var rows []SharedGameRow
+	err := r.db.Raw(
+		`SELECT
+			mine.steam_app_id,
+			COALESCE(NULLIF(library_games.name, ''), NULLIF(mine.name, ''), theirs.name) AS name,
+			mine.status AS user_status,
+			mine.rating AS user_rating,
+			theirs.status AS other_status,
+			theirs.rating AS other_rating
+		FROM progresses AS mine
+		JOIN progresses AS theirs ON theirs.steam_app_id = mine.steam_app_id
+		LEFT JOIN library_games ON library_games.id = mine.library_game_id
+		WHERE mine.user_id = ?
+		  AND theirs.user_id = ?
+		  AND mine.visibility = ?
+		  AND theirs.visibility = ?
+		ORDER BY name`,
+		userID,
+		otherID,
+		models.ProfileVisibilityPublic,
+		models.ProfileVisibilityPublic,
+	).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ProgressRepository) CountSteamGamesByUserIDs(userIDs []string) (map[string]int64, error) {
+	type countRow struct {
+		UserID string `gorm:"column:user_id"`
+		Count  int64  `gorm:"column:count"`
+	}
+	var rows []countRow
+	err := r.db.Model(&models.Progress{}).
+		Select("user_id, COUNT(DISTINCT steam_app_id) AS count").
+		Where("user_id IN ? AND steam_app_id IS NOT NULL AND visibility = ?", userIDs, models.ProfileVisibilityPublic).
+		Group("user_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts, nil
+}
+
 func (r *ProgressRepository) progressWithLibraryQuery() *gorm.DB {
 	return r.db.
 		Table("progresses").
@@ -192,7 +351,12 @@ func (r *ProgressRepository) progressWithLibraryQuery() *gorm.DB {
 			progresses.rating,
 			progresses.review,
 			progresses.steam_app_id,
+			progresses.library_game_id,
 			progresses.steam_playtime_forever,
+			progresses.platform,
+			progresses.ownership,
+			progresses.store,
+			progresses.visibility,
 			COALESCE(
 				NULLIF(library_games.capsule_image, ''),
 				NULLIF(library_games.header_image, ''),
@@ -202,5 +366,5 @@ func (r *ProgressRepository) progressWithLibraryQuery() *gorm.DB {
 			progresses.created_at,
 			progresses.updated_at
 		`).
-		Joins("LEFT JOIN library_games ON library_games.steam_app_id = progresses.steam_app_id")
+		Joins("LEFT JOIN library_games ON library_games.id = progresses.library_game_id")
 }
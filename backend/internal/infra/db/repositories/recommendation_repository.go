@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecommendationRepository struct {
+	db *gorm.DB
+}
+
+type RatedGameRow struct {
+	SteamAppID int    `gorm:"column:steam_app_id"`
+	Name       string `gorm:"column:name"`
+	Rating     int    `gorm:"column:rating"`
+}
+
+type CoRatedRow struct {
+	SteamAppID int     `gorm:"column:steam_app_id"`
+	SeedAppID  int     `gorm:"column:seed_app_id"`
+	Support    int     `gorm:"column:support"`
+	AvgRating  float64 `gorm:"column:avg_rating"`
+}
+
+type PopularGameRow struct {
+	SteamAppID int     `gorm:"column:steam_app_id"`
+	AvgRating  float64 `gorm:"column:avg_rating"`
+	Ratings    int     `gorm:"column:ratings"`
+}
+
+type RecommendationRow struct {
+	models.Recommendation
+	Name         string `gorm:"column:name"`
+	HeaderImage  string `gorm:"column:header_image"`
+	CapsuleImage string `gorm:"column:capsule_image"`
+	StoreURL     string `gorm:"column:store_url"`
+	BasedOnName  string `gorm:"column:based_on_name"`
+}
+
+func NewRecommendationRepository(db *gorm.DB) *RecommendationRepository {
+	return &RecommendationRepository{db: db}
+}
+
+func (r *RecommendationRepository) ReplaceForUser(userID string, recommendations []*models.Recommendation, computedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Recommendation{}).Error; err != nil {
+			return err
+		}
+		if len(recommendations) > 0 {
+			if err := tx.Create(&recommendations).Error; err != nil {
+				return err
+			}
+		}
+		run := &models.RecommendationRun{UserID: userID, ComputedAt: computedAt}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"computed_at"}),
+		}).Create(run).Error
+	})
+}
+
+func (r *RecommendationRepository) GetRun(userID string) (*models.RecommendationRun, error) {
+	var run models.RecommendationRun
+	if err := r.db.First(&run, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *RecommendationRepository) ListByUserID(userID string, limit, offset int) ([]RecommendationRow, error) {
+	var rows []RecommendationRow
+	err := r.recommendationQuery(userID).
+		Select(`
+			recommendations.*,
+			library_games.name,
+			library_games.header_image,
+			library_games.capsule_image,
+			library_games.store_url,
+			COALESCE(based_on.name, '') AS based_on_name
+		`).
+		Joins("LEFT JOIN library_games AS based_on ON based_on.steam_app_id = recommendations.based_on_app_id").
+		Order("recommendations.score DESC, recommendations.steam_app_id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *RecommendationRepository) CountByUserID(userID string) (int64, error) {
+	var count int64
+	err := r.recommendationQuery(userID).Count(&count).Error
+	return count, err
+}
+
+func (r *RecommendationRepository) recommendationQuery(userID string) *gorm.DB {
+	return r.db.
+		Table("recommendations").
+		Joins("JOIN library_games ON library_games.steam_app_id = recommendations.steam_app_id").
+		Where("recommendations.user_id = ?", userID).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM progresses
+			WHERE progresses.user_id = recommendations.user_id
+			  AND progresses.steam_app_id = recommendations.steam_app_id
+		)`)
+}
+
+func (r *RecommendationRepository) ListStaleUserIDs(limit int) ([]string, error) {
+	var ids []string
+	err := r.db.Raw(
+		`SELECT progresses.user_id
+		FROM progresses
+		LEFT JOIN recommendation_runs ON recommendation_runs.user_id = progresses.user_id
+		WHERE progresses.steam_app_id IS NOT NULL
+		GROUP BY progresses.user_id, recommendation_runs.computed_at
+		HAVING recommendation_runs.computed_at IS NULL
+		    OR MAX(progresses.updated_at) > recommendation_runs.computed_at
+		ORDER BY MAX(progresses.updated_at) DESC
+		LIMIT ?`,
+		limit,
+	).Scan(&ids).Error
+	return ids, err
+}
+
+func (r *RecommendationRepository) ListRatedGames(userID string) ([]RatedGameRow, error) {
+	var rows []RatedGameRow
+	err := r.db.
+		Table("progresses").
+		Select("progresses.steam_app_id, COALESCE(NULLIF(library_games.name, ''), progresses.name) AS name, progresses.rating").
+		Joins("LEFT JOIN library_games ON library_games.steam_app_id = progresses.steam_app_id").
+		Where("progresses.user_id = ? AND progresses.steam_app_id IS NOT NULL AND progresses.rating IS NOT NULL", userID).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *RecommendationRepository) ListOwnedAppIDs(userID string) ([]int, error) {
+	var ids []int
+	err := r.db.Model(&models.Progress{}).
+		Where("user_id = ? AND steam_app_id IS NOT NULL", userID).
+		Pluck("steam_app_id", &ids).Error
+	return ids, err
+}
+
+func (r *RecommendationRepository) ListCoRated(userID string, minRating int) ([]CoRatedRow, error) {
+	var rows []CoRatedRow
+	err := r.db.Raw(
+		`SELECT
+			candidate.steam_app_id,
+			seed.steam_app_id AS seed_app_id,
+			COUNT(DISTINCT candidate.user_id) AS support,
+			AVG(candidate.rating)::double precision AS avg_rating
+		FROM progresses AS mine
+		JOIN progresses AS seed
+		  ON seed.steam_app_id = mine.steam_app_id
+		 AND seed.user_id <> mine.user_id
+		 AND seed.rating >= ?
+		 AND seed.visibility = ?
+		JOIN progresses AS candidate
+		  ON candidate.user_id = seed.user_id
+		 AND candidate.steam_app_id IS NOT NULL
+		 AND candidate.rating >= ?
+		 AND candidate.visibility = ?
+		WHERE mine.user_id = ?
+		  AND mine.rating >= ?
+		  AND NOT EXISTS (
+			SELECT 1 FROM progresses AS owned
+			WHERE owned.user_id = mine.user_id
+			  AND owned.steam_app_id = candidate.steam_app_id
+		  )
+		GROUP BY candidate.steam_app_id, seed.steam_app_id`,
+		minRating,
+		models.ProfileVisibilityPublic,
+		minRating,
+		models.ProfileVisibilityPublic,
+		userID,
+		minRating,
+	).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *RecommendationRepository) ListPopular(minRatings, limit int) ([]PopularGameRow, error) {
+	var rows []PopularGameRow
+	err := r.db.
+		Table("progresses").
+		Select("steam_app_id, AVG(rating)::double precision AS avg_rating, COUNT(rating) AS ratings").
+		Where("steam_app_id IS NOT NULL AND rating IS NOT NULL AND visibility = ?", models.ProfileVisibilityPublic).
+		Group("steam_app_id").
+		Having("COUNT(rating) >= ?", minRatings).
+		Order("avg_rating DESC, ratings DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
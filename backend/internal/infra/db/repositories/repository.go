@@ -3,12 +3,24 @@ package repositories
 import "gorm.io/gorm"
 
 type Repository struct {
-	User         *UserRepository
-	Progress     *ProgressRepository
-	Activity     *ActivityRepository
-	Library      *LibraryRepository
-	Token        *TokenRepository
-	Subscription *SubscriptionRepository
+	User           *UserRepository
+	Progress       *ProgressRepository
+	Activity       *ActivityRepository
+	Library        *LibraryRepository
+	Token          *TokenRepository
+	Subscription   *SubscriptionRepository
+	Review         *ReviewRepository
+	Comment        *ActivityCommentRepository
+	Notification   *NotificationRepository
+	Webhook        *WebhookRepository
+	Block          *BlockRepository
+	Mute           *MuteRepository
+	Recommendation *RecommendationRepository
+	SimilarGame    *SimilarGameRepository
+	Search         *SearchRepository
+	Chart          *ChartRepository
+	Series         *SeriesRepository
+	ProgressImport *ProgressImportRepository
 }
 
 func New(
@@ -18,14 +30,38 @@ func New(
 	libraryRepo *LibraryRepository,
 	tokenRepo *TokenRepository,
 	subscriptionRepo *SubscriptionRepository,
+	reviewRepo *ReviewRepository,
+	commentRepo *ActivityCommentRepository,
+	notificationRepo *NotificationRepository,
+	webhookRepo *WebhookRepository,
+	blockRepo *BlockRepository,
+	muteRepo *MuteRepository,
+	recommendationRepo *RecommendationRepository,
+	similarGameRepo *SimilarGameRepository,
+	searchRepo *SearchRepository,
+	chartRepo *ChartRepository,
+	seriesRepo *SeriesRepository,
+	progressImportRepo *ProgressImportRepository,
 ) *Repository {
 	return &Repository{
-		User:         userRepo,
-		Progress:     progressRepo,
-		Activity:     activityRepo,
-		Library:      libraryRepo,
-		Token:        tokenRepo,
-		Subscription: subscriptionRepo,
+		User:           userRepo,
+		Progress:       progressRepo,
+		Activity:       activityRepo,
+		Library:        libraryRepo,
+		Token:          tokenRepo,
+		Subscription:   subscriptionRepo,
+		Review:         reviewRepo,
+		Comment:        commentRepo,
+		Notification:   notificationRepo,
+		Webhook:        webhookRepo,
+		Block:          blockRepo,
+		Mute:           muteRepo,
+		Recommendation: recommendationRepo,
+		SimilarGame:    similarGameRepo,
+		Search:         searchRepo,
+		Chart:          chartRepo,
+		Series:         seriesRepo,
+		ProgressImport: progressImportRepo,
 	}
 }
 
@@ -37,5 +73,17 @@ func NewRepositories(db *gorm.DB) *Repository {
 		NewLibraryRepository(db),
 		NewTokenRepository(db),
 		NewSubscriptionRepository(db),
+		NewReviewRepository(db),
+		NewActivityCommentRepository(db),
+		NewNotificationRepository(db),
+		NewWebhookRepository(db),
+		NewBlockRepository(db),
+		NewMuteRepository(db),
+		NewRecommendationRepository(db),
+		NewSimilarGameRepository(db),
+		NewSearchRepository(db),
+		NewChartRepository(db),
+		NewSeriesRepository(db),
+		NewProgressImportRepository(db),
 	)
 }
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+type ReviewReportRow struct {
+	ID                  string                    `gorm:"column:id"`
+	ProgressID          string                    `gorm:"column:progress_id"`
+	Reason              models.ReviewReportReason `gorm:"column:reason"`
+	Details             string                    `gorm:"column:details"`
+	Status              models.ReviewReportStatus `gorm:"column:status"`
+	CreatedAt           time.Time                 `gorm:"column:created_at"`
+	ResolvedAt          *time.Time                `gorm:"column:resolved_at"`
+	ReporterID          string                    `gorm:"column:reporter_id"`
+	ReporterDisplayName string                    `gorm:"column:reporter_display_name"`
+	ReporterAvatarURL   string                    `gorm:"column:reporter_avatar_url"`
+	AuthorID            string                    `gorm:"column:author_id"`
+	AuthorDisplayName   string                    `gorm:"column:author_display_name"`
+	AuthorAvatarURL     string                    `gorm:"column:author_avatar_url"`
+	Review              string                    `gorm:"column:review"`
+	Rating              *int                      `gorm:"column:rating"`
+	GameName            string                    `gorm:"column:game_name"`
+	SteamAppID          *int                      `gorm:"column:steam_app_id"`
+	ReportsCount        int                       `gorm:"column:reports_count"`
+}
+
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+func (r *ReviewRepository) UpsertVote(vote *models.ReviewVote) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "progress_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"helpful", "updated_at"}),
+	}).Create(vote).Error
+}
+
+func (r *ReviewRepository) DeleteVote(progressID, userID string) error {
+	return r.db.Delete(&models.ReviewVote{}, "progress_id = ? AND user_id = ?", progressID, userID).Error
+}
+
+func (r *ReviewRepository) GetVoteCounts(progressID string) (int64, int64, error) {
+	var counts struct {
+		HelpfulCount   int64
+		UnhelpfulCount int64
+	}
+	err := r.db.Model(&models.ReviewVote{}).
+		Where("progress_id = ?", progressID).
+		Select(`
+			COALESCE(SUM(CASE WHEN helpful THEN 1 ELSE 0 END), 0) AS helpful_count,
+			COALESCE(SUM(CASE WHEN helpful THEN 0 ELSE 1 END), 0) AS unhelpful_count
+		`).
+		Row().
+		Scan(&counts.HelpfulCount, &counts.UnhelpfulCount)
+	return counts.HelpfulCount, counts.UnhelpfulCount, err
+}
+
+func (r *ReviewRepository) GetHelpfulScoreByUserID(userID string) (int64, error) {
+	var score int64
+	err := r.db.Raw(
+		`SELECT COALESCE(SUM(CASE WHEN review_votes.helpful THEN 1 ELSE -1 END), 0)
+		FROM review_votes
+		JOIN progresses ON progresses.id = review_votes.progress_id
+		WHERE progresses.user_id = ?
+		  AND TRIM(COALESCE(progresses.review, '')) <> ''`,
+		userID,
+	).Scan(&score).Error
+	return score, err
+}
+
+func (r *ReviewRepository) CreateReport(report *models.ReviewReport) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "progress_id"}, {Name: "reporter_id"}},
+		DoNothing: true,
+	}).Create(report)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ReviewRepository) GetReportByID(id string) (*models.ReviewReport, error) {
+	var report models.ReviewReport
+	if err := r.db.First(&report, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *ReviewRepository) DismissReport(id, moderatorID string) (bool, error) {
+	result := r.db.Model(&models.ReviewReport{}).
+		Where("id = ? AND status = ?", id, models.ReviewReportStatusPending).
+		Updates(resolvedReportColumns(models.ReviewReportStatusDismissed, moderatorID))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ReviewRepository) RemoveReview(reportID, progressID, moderatorID string) (bool, error) {
+	removed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ReviewReport{}).
+			Where("id = ? AND status = ?", reportID, models.ReviewReportStatusPending).
+			Updates(resolvedReportColumns(models.ReviewReportStatusResolved, moderatorID))
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Model(&models.ReviewReport{}).
+			Where("progress_id = ? AND status = ?", progressID, models.ReviewReportStatusPending).
+			Updates(resolvedReportColumns(models.ReviewReportStatusResolved, moderatorID)).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Progress{}).Where("id = ?", progressID).Update("review", "").Error; err != nil {
+			return err
+		}
+		if err := tx.Where("progress_id = ?", progressID).Delete(&models.ReviewVote{}).Error; err != nil {
+			return err
+		}
+		removed = true
+		return nil
+	})
+	return removed, err
+}
+
+func resolvedReportColumns(status models.ReviewReportStatus, moderatorID string) map[string]interface{} {
+	return map[string]interface{}{
+		"status":         status,
+		"resolved_by_id": moderatorID,
+		"resolved_at":    time.Now(),
+	}
+}
+
+func (r *ReviewRepository) CountReports(status string) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.ReviewReport{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+func (r *ReviewRepository) ListReports(status string, limit, offset int) ([]ReviewReportRow, error) {
+	var rows []ReviewReportRow
+	query := r.db.
+		Table("review_reports").
+		Select(`
+			review_reports.id,
+			review_reports.progress_id,
+			review_reports.reason,
+			review_reports.details,
+			review_reports.status,
+			review_reports.created_at,
+			review_reports.resolved_at,
+			reporters.id AS reporter_id,
+			reporters.display_name AS reporter_display_name,
+			reporters.avatar_url AS reporter_avatar_url,
+			authors.id AS author_id,
+			authors.display_name AS author_display_name,
+			authors.avatar_url AS author_avatar_url,
+			progresses.review,
+			progresses.rating,
+			COALESCE(NULLIF(library_games.name, ''), progresses.name) AS game_name,
+			progresses.steam_app_id,
+			(
+				SELECT COUNT(*)
+				FROM review_reports AS siblings
+				WHERE siblings.progress_id = review_reports.progress_id
+				  AND siblings.status = ?
+			) AS reports_count
+		`, models.ReviewReportStatusPending).
+		Joins("JOIN progresses ON progresses.id = review_reports.progress_id").
+		Joins("JOIN users AS reporters ON reporters.id = review_reports.reporter_id").
+		Joins("JOIN users AS authors ON authors.id = progresses.user_id").
+		Joins("LEFT JOIN library_games ON library_games.id = progresses.library_game_id")
+	if status != "" {
+		query = query.Where("review_reports.status = ?", status)
+	}
+	err := query.
+		Order("review_reports.created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+)
+
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+type GameSearchRow struct {
+	ID           string  `json:"id"`
+	SteamAppID   *int    `json:"steamAppId,omitempty"`
+	Name         string  `json:"name"`
+	HeaderImage  string  `json:"headerImage,omitempty"`
+	CapsuleImage string  `json:"capsuleImage,omitempty"`
+	PrimaryGenre string  `json:"primaryGenre,omitempty"`
+	Rank         float64 `json:"rank"`
+	Highlight    string  `json:"highlight"`
+}
+
+type UserSearchRow struct {
+	ID          string  `json:"id"`
+	DisplayName string  `json:"displayName"`
+	AvatarURL   string  `json:"avatarUrl,omitempty"`
+	Rank        float64 `json:"rank"`
+	Highlight   string  `json:"highlight"`
+}
+
+type ReviewSearchRow struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"userId"`
+	DisplayName string    `json:"displayName"`
+	AvatarURL   string    `json:"avatarUrl,omitempty"`
+	SteamAppID  *int      `json:"steamAppId,omitempty"`
+	GameName    string    `json:"gameName"`
+	Rating      *int      `json:"rating,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Rank        float64   `json:"rank"`
+	Highlight   string    `json:"highlight"`
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+func (r *SearchRepository) SearchGames(query string, limit int) ([]GameSearchRow, error) {
+	var rows []GameSearchRow
+	err := r.db.
+		Table("library_games").
+		Select(`
+			library_games.id,
+			library_games.steam_app_id,
+			library_games.name,
+			library_games.header_image,
+			library_games.capsule_image,
+			library_games.primary_genre,
+			ts_rank(library_games.search_vector, websearch_to_tsquery('simple', @query)) + GREATEST(similarity(library_games.name, @query), word_similarity(@query, library_games.name)) AS rank,
+			ts_headline('simple', `+escapeHTMLSQL("library_games.name || '. ' || COALESCE(NULLIF(library_games.short_description, ''), library_games.description, '')")+`, websearch_to_tsquery('simple', @query), @options) AS highlight
+		`, map[string]interface{}{"query": query, "options": searchHeadlineOptions}).
+		Where(`(library_games.search_vector @@ websearch_to_tsquery('simple', @query)
+			OR library_games.name % @query
+			OR @query <% library_games.name)`, map[string]interface{}{"query": query}).
+		Where("library_games.moderation_status = ?", models.LibraryGameModerationApproved).
+		Order("rank DESC, library_games.name ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SearchRepository) SearchUsers(query, viewerID string, limit int) ([]UserSearchRow, error) {
+	db := r.db.
+		Table("users").
+		Select(`
+			users.id,
+			users.display_name,
+			users.avatar_url,
+			ts_rank(users.search_vector, websearch_to_tsquery('simple', @query)) + GREATEST(similarity(users.display_name, @query), word_similarity(@query, users.display_name)) AS rank,
+			ts_headline('simple', `+escapeHTMLSQL("users.display_name")+`, websearch_to_tsquery('simple', @query), @options) AS highlight
+		`, map[string]interface{}{"query": query, "options": searchHeadlineOptions}).
+		Where(`(users.search_vector @@ websearch_to_tsquery('simple', @query)
+			OR users.display_name % @query
+			OR @query <% users.display_name)`, map[string]interface{}{"query": query})
+	if viewerID != "" {
+		blocked, args := notBlockedCondition("users.id", viewerID)
+		db = db.Where(blocked, args...)
+	}
+
+	var rows []UserSearchRow
+	err := db.
+		Order("rank DESC, users.display_name ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SearchRepository) SearchReviews(query, viewerID string, limit int) ([]ReviewSearchRow, error) {
+	db := r.db.
+		Table("progresses").
+		Select(`
+			progresses.id,
+			progresses.user_id,
+			users.display_name,
+			users.avatar_url,
+			progresses.steam_app_id,
+			COALESCE(library_games.name, progresses.name) AS game_name,
+			progresses.rating,
+			progresses.created_at,
+			ts_rank(progresses.review_search_vector, websearch_to_tsquery('simple', @query)) + word_similarity(@query, progresses.review) AS rank,
+			ts_headline('simple', `+escapeHTMLSQL("progresses.review")+`, websearch_to_tsquery('simple', @query), @options) AS highlight
+		`, map[string]interface{}{"query": query, "options": searchHeadlineOptions}).
+		Joins("JOIN users ON users.id = progresses.user_id").
+		Joins("LEFT JOIN library_games ON library_games.id = progresses.library_game_id").
+		Where(`(progresses.review_search_vector @@ websearch_to_tsquery('simple', @query)
+			OR @query <% progresses.review)`, map[string]interface{}{"query": query}).
+		Where("TRIM(COALESCE(progresses.review, '')) <> ''").
+		Where("progresses.visibility = ?", models.ProfileVisibilityPublic)
+
+	visibility, visibilityArgs := profileVisibleCondition("users.id", "users.profile_visibility", viewerID)
+	db = db.Where(visibility, visibilityArgs...)
+	if viewerID != "" {
+		blocked, args := notBlockedCondition("users.id", viewerID)
+		db = db.Where(blocked, args...)
+	}
+
+	var rows []ReviewSearchRow
+	err := db.
+		Order("rank DESC, progresses.created_at DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func escapeHTMLSQL(expr string) string {
+	return "replace(replace(replace(replace(replace(" + expr + `, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
@@ -0,0 +1,278 @@
+package repositories
+
+import (
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type SeriesRepository struct {
+	db *gorm.DB
+}
+
+type SeriesListRow struct {
+	models.GameSeries
+	GameCount  int64  `gorm:"column:game_count"`
+	CoverImage string `gorm:"column:cover_image"`
+}
+
+type SeriesEntryRow struct {
+	models.LibraryGame
+	Position int `gorm:"column:position"`
+}
+
+type SeriesPlacementRow struct {
+	SeriesID            string `gorm:"column:series_id"`
+	SeriesName          string `gorm:"column:series_name"`
+	SeriesSlug          string `gorm:"column:series_slug"`
+	Position            int    `gorm:"column:position"`
+	GameCount           int    `gorm:"column:game_count"`
+	PreviousGameID      string `gorm:"column:previous_game_id"`
+	PreviousName        string `gorm:"column:previous_name"`
+	PreviousHeaderImage string `gorm:"column:previous_header_image"`
+	PreviousSteamAppID  *int   `gorm:"column:previous_steam_app_id"`
+	NextGameID          string `gorm:"column:next_game_id"`
+	NextName            string `gorm:"column:next_name"`
+	NextHeaderImage     string `gorm:"column:next_header_image"`
+	NextSteamAppID      *int   `gorm:"column:next_steam_app_id"`
+}
+
+type SeriesProgressRow struct {
+	GameID      string             `gorm:"column:game_id"`
+	Name        string             `gorm:"column:name"`
+	HeaderImage string             `gorm:"column:header_image"`
+	SteamAppID  *int               `gorm:"column:steam_app_id"`
+	Position    int                `gorm:"column:position"`
+	ProgressID  *string            `gorm:"column:progress_id"`
+	Status      *models.GameStatus `gorm:"column:status"`
+	Rating      *int               `gorm:"column:rating"`
+}
+
+type SeriesCandidateRow struct {
+	ID         string `gorm:"column:id"`
+	Name       string `gorm:"column:name"`
+	SteamAppID *int   `gorm:"column:steam_app_id"`
+}
+
+func NewSeriesRepository(db *gorm.DB) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+func (r *SeriesRepository) Create(series *models.GameSeries, gameIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(series).Error; err != nil {
+			return err
+		}
+		return createSeriesEntries(tx, series.ID, gameIDs, 1)
+	})
+}
+
+func (r *SeriesRepository) Update(series *models.GameSeries) error {
+	return r.db.Save(series).Error
+}
+
+func (r *SeriesRepository) Delete(id string) error {
+	result := r.db.Delete(&models.GameSeries{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *SeriesRepository) GetByID(id string) (*models.GameSeries, error) {
+	var series models.GameSeries
+	if err := r.db.First(&series, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *SeriesRepository) GetBySlug(slug string) (*models.GameSeries, error) {
+	var series models.GameSeries
+	if err := r.db.First(&series, "slug = ?", slug).Error; err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *SeriesRepository) ExistsBySlug(slug string) (bool, error) {
+	var exists bool
+	err := r.db.Raw("SELECT EXISTS(SELECT 1 FROM game_series WHERE slug = ?)", slug).Scan(&exists).Error
+	return exists, err
+}
+
+func (r *SeriesRepository) List(search string, limit, offset int) ([]SeriesListRow, error) {
+	var rows []SeriesListRow
+	err := r.listQuery(search).
+		Select(`
+			game_series.*,
+			(
+				SELECT COUNT(*)
+				FROM game_series_entries
+				JOIN library_games ON library_games.id = game_series_entries.game_id
+				WHERE game_series_entries.series_id = game_series.id
+				  AND library_games.moderation_status = ?
+			) AS game_count,
+			COALESCE((
+				SELECT library_games.header_image
+				FROM game_series_entries
+				JOIN library_games ON library_games.id = game_series_entries.game_id
+				WHERE game_series_entries.series_id = game_series.id
+				  AND library_games.moderation_status = ?
+				ORDER BY game_series_entries.position ASC
+				LIMIT 1
+			), '') AS cover_image
+		`, models.LibraryGameModerationApproved, models.LibraryGameModerationApproved).
+		Order("game_series.name ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SeriesRepository) Count(search string) (int64, error) {
+	var count int64
+	err := r.listQuery(search).Count(&count).Error
+	return count, err
+}
+
+func (r *SeriesRepository) listQuery(search string) *gorm.DB {
+	query := r.db.Table("game_series")
+	if search != "" {
+		query = query.Where("game_series.name ILIKE ?", "%"+search+"%")
+	}
+	return query
+}
+
+func (r *SeriesRepository) ListEntries(seriesID string) ([]SeriesEntryRow, error) {
+	var rows []SeriesEntryRow
+	err := r.db.
+		Table("game_series_entries").
+		Select("library_games.*, game_series_entries.position").
+		Joins("JOIN library_games ON library_games.id = game_series_entries.game_id").
+		Where("game_series_entries.series_id = ?", seriesID).
+		Where("library_games.moderation_status = ?", models.LibraryGameModerationApproved).
+		Order("game_series_entries.position ASC, library_games.name ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SeriesRepository) ReplaceEntries(seriesID string, gameIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", seriesID).Delete(&models.GameSeriesEntry{}).Error; err != nil {
+			return err
+		}
+		return createSeriesEntries(tx, seriesID, gameIDs, 1)
+	})
+}
+
+func (r *SeriesRepository) AppendEntries(seriesID string, gameIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&models.GameSeriesEntry{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("series_id = ?", seriesID).
+			Scan(&last).Error; err != nil {
+			return err
+		}
+		return createSeriesEntries(tx, seriesID, gameIDs, last+1)
+	})
+}
+
+func createSeriesEntries(tx *gorm.DB, seriesID string, gameIDs []string, start int) error {
+	if len(gameIDs) == 0 {
+		return nil
+	}
+	entries := make([]*models.GameSeriesEntry, 0, len(gameIDs))
+	for i, gameID := range gameIDs {
+		entries = append(entries, &models.GameSeriesEntry{
+			SeriesID: seriesID,
+			GameID:   gameID,
+			Position: start + i,
+		})
+	}
+	return tx.Create(&entries).Error
+}
+
+func (r *SeriesRepository) ListPlacementsByGameID(gameID string) ([]SeriesPlacementRow, error) {
+	entries := r.db.
+		Table("game_series_entries").
+		Select(`
+			game_series_entries.series_id,
+			game_series_entries.game_id,
+			ROW_NUMBER() OVER (PARTITION BY game_series_entries.series_id ORDER BY game_series_entries.position, library_games.name) AS position,
+			COUNT(*) OVER (PARTITION BY game_series_entries.series_id) AS game_count,
+			LAG(game_series_entries.game_id) OVER (PARTITION BY game_series_entries.series_id ORDER BY game_series_entries.position, library_games.name) AS previous_game_id,
+			LEAD(game_series_entries.game_id) OVER (PARTITION BY game_series_entries.series_id ORDER BY game_series_entries.position, library_games.name) AS next_game_id
+		`).
+		Joins("JOIN library_games ON library_games.id = game_series_entries.game_id").
+		Where("library_games.moderation_status = ?", models.LibraryGameModerationApproved).
+		Where("game_series_entries.series_id IN (SELECT series_id FROM game_series_entries WHERE game_id = ?)", gameID)
+
+	var rows []SeriesPlacementRow
+	err := r.db.
+		Table("(?) AS entries", entries).
+		Select(`
+			game_series.id AS series_id,
+			game_series.name AS series_name,
+			game_series.slug AS series_slug,
+			entries.position,
+			entries.game_count,
+			COALESCE(previous_game.id::text, '') AS previous_game_id,
+			COALESCE(previous_game.name, '') AS previous_name,
+			COALESCE(previous_game.header_image, '') AS previous_header_image,
+			previous_game.steam_app_id AS previous_steam_app_id,
+			COALESCE(next_game.id::text, '') AS next_game_id,
+			COALESCE(next_game.name, '') AS next_name,
+			COALESCE(next_game.header_image, '') AS next_header_image,
+			next_game.steam_app_id AS next_steam_app_id
+		`).
+		Joins("JOIN game_series ON game_series.id = entries.series_id").
+		Joins("LEFT JOIN library_games AS previous_game ON previous_game.id = entries.previous_game_id").
+		Joins("LEFT JOIN library_games AS next_game ON next_game.id = entries.next_game_id").
+		Where("entries.game_id = ?", gameID).
+		Order("game_series.name ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SeriesRepository) ListUserProgress(seriesID, userID, viewerID string) ([]SeriesProgressRow, error) {
+	visibility, visibilityArgs := entryVisibleCondition("progresses.user_id", "progresses.visibility", viewerID)
+	joinArgs := append([]interface{}{userID}, visibilityArgs...)
+
+	var rows []SeriesProgressRow
+	err := r.db.
+		Table("game_series_entries").
+		Select(`
+			library_games.id AS game_id,
+			library_games.name,
+			library_games.header_image,
+			library_games.steam_app_id,
+			game_series_entries.position,
+			progresses.id AS progress_id,
+			progresses.status,
+			progresses.rating
+		`).
+		Joins("JOIN library_games ON library_games.id = game_series_entries.game_id").
+		Joins("LEFT JOIN progresses ON progresses.library_game_id = library_games.id AND progresses.user_id = ? AND "+visibility, joinArgs...).
+		Where("game_series_entries.series_id = ?", seriesID).
+		Where("library_games.moderation_status = ?", models.LibraryGameModerationApproved).
+		Order("game_series_entries.position ASC, library_games.name ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SeriesRepository) ListUnassignedGames() ([]SeriesCandidateRow, error) {
+	var rows []SeriesCandidateRow
+	err := r.db.
+		Table("library_games").
+		Select("library_games.id, library_games.name, library_games.steam_app_id").
+		Where("library_games.moderation_status = ?", models.LibraryGameModerationApproved).
+		Where("NOT EXISTS (SELECT 1 FROM game_series_entries WHERE game_series_entries.game_id = library_games.id)").
+		Order("library_games.created_at ASC").
+		Scan(&rows).Error
+	return rows, err
+}
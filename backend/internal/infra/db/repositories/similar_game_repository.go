@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SimilarGameRepository struct {
+	db *gorm.DB
+}
+
+type SimilarGameRow struct {
+	models.LibraryGame
+	Score        float64 `gorm:"column:score"`
+	TagScore     float64 `gorm:"column:tag_score"`
+	CoRatedScore float64 `gorm:"column:co_rated_score"`
+}
+
+type CoRatedPairRow struct {
+	SteamAppID int `gorm:"column:steam_app_id"`
+	OtherAppID int `gorm:"column:other_app_id"`
+	Users      int `gorm:"column:users"`
+}
+
+type HighRatedCountRow struct {
+	SteamAppID int `gorm:"column:steam_app_id"`
+	Users      int `gorm:"column:users"`
+}
+
+func NewSimilarGameRepository(db *gorm.DB) *SimilarGameRepository {
+	return &SimilarGameRepository{db: db}
+}
+
+func (r *SimilarGameRepository) ReplaceForGame(gameID string, similar []*models.SimilarGame, computedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("game_id = ?", gameID).Delete(&models.SimilarGame{}).Error; err != nil {
+			return err
+		}
+		if len(similar) > 0 {
+			if err := tx.Create(&similar).Error; err != nil {
+				return err
+			}
+		}
+		run := &models.SimilarGameRun{GameID: gameID, ComputedAt: computedAt}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "game_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"computed_at"}),
+		}).Create(run).Error
+	})
+}
+
+func (r *SimilarGameRepository) IsComputed(gameID string) (bool, error) {
+	var exists bool
+	err := r.db.Raw("SELECT EXISTS(SELECT 1 FROM similar_game_runs WHERE game_id = ?)", gameID).Scan(&exists).Error
+	return exists, err
+}
+
+func (r *SimilarGameRepository) ListByGameID(gameID string, limit, offset int) ([]SimilarGameRow, error) {
+	var rows []SimilarGameRow
+	err := r.db.
+		Table("similar_games").
+		Select("library_games.*, similar_games.score, similar_games.tag_score, similar_games.co_rated_score").
+		Joins("JOIN library_games ON library_games.id = similar_games.similar_game_id").
+		Where("similar_games.game_id = ?", gameID).
+		Order("similar_games.score DESC, library_games.name ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SimilarGameRepository) CountByGameID(gameID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.SimilarGame{}).Where("game_id = ?", gameID).Count(&count).Error
+	return count, err
+}
+
+func (r *SimilarGameRepository) ListCoRatedPairs(minRating int, appID *int) ([]CoRatedPairRow, error) {
+	query := r.db.
+		Table("progresses AS first").
+		Select("first.steam_app_id, second.steam_app_id AS other_app_id, COUNT(DISTINCT first.user_id) AS users").
+		Joins(`JOIN progresses AS second
+			ON second.user_id = first.user_id
+			AND second.steam_app_id IS NOT NULL
+			AND second.steam_app_id <> first.steam_app_id
+			AND second.rating >= ?
+			AND second.visibility = ?`, minRating, models.ProfileVisibilityPublic).
+		Where("first.steam_app_id IS NOT NULL AND first.rating >= ? AND first.visibility = ?", minRating, models.ProfileVisibilityPublic).
+		Group("first.steam_app_id, second.steam_app_id")
+	if appID != nil {
+		query = query.Where("first.steam_app_id = ?", *appID)
+	}
+
+	var rows []CoRatedPairRow
+	err := query.Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SimilarGameRepository) CountHighRated(minRating int) (map[int]int, error) {
+	var rows []HighRatedCountRow
+	err := r.db.
+		Table("progresses").
+		Select("steam_app_id, COUNT(DISTINCT user_id) AS users").
+		Where("steam_app_id IS NOT NULL AND rating >= ? AND visibility = ?", minRating, models.ProfileVisibilityPublic).
+		Group("steam_app_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.SteamAppID] = row.Users
+	}
+	return counts, nil
+}
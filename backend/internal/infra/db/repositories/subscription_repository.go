@@ -1,6 +1,10 @@
 package repositories
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"gamecheck/internal/domain/models"
 
 	"gorm.io/gorm"
@@ -10,6 +14,35 @@ type SubscriptionRepository struct {
 	db *gorm.DB
 }
 
+type FollowRequestRow struct {
+	UserID      string    `gorm:"column:user_id"`
+	DisplayName string    `gorm:"column:display_name"`
+	AvatarURL   string    `gorm:"column:avatar_url"`
+	RequestedAt time.Time `gorm:"column:requested_at"`
+}
+
+type FollowListKind string
+
+const (
+	FollowListFollowers FollowListKind = "followers"
+	FollowListFollowing FollowListKind = "following"
+	FollowListMutual    FollowListKind = "mutual"
+)
+
+type FollowListRow struct {
+	UserID      string    `gorm:"column:user_id"`
+	DisplayName string    `gorm:"column:display_name"`
+	AvatarURL   string    `gorm:"column:avatar_url"`
+	FollowedAt  time.Time `gorm:"column:followed_at"`
+	FollowsYou  bool      `gorm:"column:follows_you"`
+	YouFollow   bool      `gorm:"column:you_follow"`
+}
+
+type FollowCounts struct {
+	Followers int64 `gorm:"column:followers"`
+	Following int64 `gorm:"column:following"`
+}
+
 func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
 	return &SubscriptionRepository{db: db}
 }
@@ -29,32 +62,149 @@ func (r *SubscriptionRepository) GetByID(id string) (*models.Subscription, error
 	return &sub, nil
 }
 
-func (r *SubscriptionRepository) GetFollowers(userID string) ([]*models.User, error) {
-	var users []*models.User
-	err := r.db.
-		Joins("INNER JOIN subscriptions ON subscriptions.follower_id = users.id").
-		Where("subscriptions.following_id = ?", userID).
-		Find(&users).Error
-	return users, err
+func (r *SubscriptionRepository) ListFollowList(kind FollowListKind, userID, viewerID, search, order string, limit, offset int) ([]FollowListRow, error) {
+	flags := "FALSE AS follows_you, FALSE AS you_follow"
+	var flagArgs []interface{}
+	if viewerID != "" {
+		flags = `EXISTS (
+				SELECT 1 FROM subscriptions AS viewer_followers
+				WHERE viewer_followers.follower_id = users.id
+				  AND viewer_followers.following_id = ?
+				  AND viewer_followers.status = ?
+			) AS follows_you,
+			EXISTS (
+				SELECT 1 FROM subscriptions AS viewer_following
+				WHERE viewer_following.follower_id = ?
+				  AND viewer_following.following_id = users.id
+				  AND viewer_following.status = ?
+			) AS you_follow`
+		flagArgs = []interface{}{
+			viewerID, models.SubscriptionStatusAccepted,
+			viewerID, models.SubscriptionStatusAccepted,
+		}
+	}
+
+	order = strings.ToLower(order)
+	if order != "asc" {
+		order = "desc"
+	}
+
+	var rows []FollowListRow
+	err := r.followListQuery(kind, userID, viewerID, search).
+		Select(`
+			users.id AS user_id,
+			users.display_name,
+			users.avatar_url,
+			subscriptions.created_at AS followed_at,
+			`+flags, flagArgs...).
+		Order(fmt.Sprintf("subscriptions.created_at %s, users.id %s", order, order)).
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
 }
 
-func (r *SubscriptionRepository) GetFollowing(userID string) ([]*models.User, error) {
-	var users []*models.User
-	err := r.db.
-		Joins("INNER JOIN subscriptions ON subscriptions.following_id = users.id").
-		Where("subscriptions.follower_id = ?", userID).
-		Find(&users).Error
-	return users, err
+func (r *SubscriptionRepository) CountFollowList(kind FollowListKind, userID, viewerID, search string) (int64, error) {
+	var count int64
+	err := r.followListQuery(kind, userID, viewerID, search).Count(&count).Error
+	return count, err
+}
+
+func (r *SubscriptionRepository) followListQuery(kind FollowListKind, userID, viewerID, search string) *gorm.DB {
+	query := r.db.Table("subscriptions")
+	switch kind {
+	case FollowListFollowing:
+		query = query.
+			Joins("JOIN users ON users.id = subscriptions.following_id").
+			Where("subscriptions.follower_id = ?", userID)
+	case FollowListMutual:
+		query = query.
+			Joins("JOIN users ON users.id = subscriptions.follower_id").
+			Where("subscriptions.following_id = ?", userID).
+			Where(`EXISTS (
+				SELECT 1 FROM subscriptions AS reverse
+				WHERE reverse.follower_id = subscriptions.following_id
+				  AND reverse.following_id = subscriptions.follower_id
+				  AND reverse.status = ?
+			)`, models.SubscriptionStatusAccepted)
+	default:
+		query = query.
+			Joins("JOIN users ON users.id = subscriptions.follower_id").
+			Where("subscriptions.following_id = ?", userID)
+	}
+
+	query = query.Where("subscriptions.status = ?", models.SubscriptionStatusAccepted)
+	if search = strings.TrimSpace(search); search != "" {
+		query = query.Where("users.display_name ILIKE ?", "%"+search+"%")
+	}
+	if viewerID != "" {
+		blocked, args := notBlockedCondition("users.id", viewerID)
+		query = query.Where(blocked, args...)
+	}
+	return query
 }
 
 func (r *SubscriptionRepository) IsFollowing(followerID, followingID string) (bool, error) {
 	var count int64
 	err := r.db.Model(&models.Subscription{}).
-		Where("follower_id = ? AND following_id = ?", followerID, followingID).
+		Where("follower_id = ? AND following_id = ? AND status = ?", followerID, followingID, models.SubscriptionStatusAccepted).
 		Count(&count).Error
 	return count > 0, err
 }
 
+func (r *SubscriptionRepository) GetByUsers(followerID, followingID string) (*models.Subscription, error) {
+	var sub models.Subscription
+	if err := r.db.First(&sub, "follower_id = ? AND following_id = ?", followerID, followingID).Error; err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *SubscriptionRepository) GetPendingRequests(userID string, limit, offset int) ([]FollowRequestRow, error) {
+	var rows []FollowRequestRow
+	err := r.db.
+		Table("subscriptions").
+		Select(`
+			users.id AS user_id,
+			users.display_name,
+			users.avatar_url,
+			subscriptions.created_at AS requested_at
+		`).
+		Joins("JOIN users ON users.id = subscriptions.follower_id").
+		Where("subscriptions.following_id = ? AND subscriptions.status = ?", userID, models.SubscriptionStatusPending).
+		Order("subscriptions.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SubscriptionRepository) CountPendingRequests(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Subscription{}).
+		Where("following_id = ? AND status = ?", userID, models.SubscriptionStatusPending).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *SubscriptionRepository) Approve(followerID, followingID string) (int64, error) {
+	tx := r.db.Model(&models.Subscription{}).
+		Where("follower_id = ? AND following_id = ? AND status = ?", followerID, followingID, models.SubscriptionStatusPending).
+		Update("status", models.SubscriptionStatusAccepted)
+	return tx.RowsAffected, tx.Error
+}
+
+func (r *SubscriptionRepository) ApproveAllPending(followingID string) error {
+	return r.db.Model(&models.Subscription{}).
+		Where("following_id = ? AND status = ?", followingID, models.SubscriptionStatusPending).
+		Update("status", models.SubscriptionStatusAccepted).Error
+}
+
+func (r *SubscriptionRepository) DeletePending(followerID, followingID string) (int64, error) {
+	tx := r.db.Delete(&models.Subscription{}, "follower_id = ? AND following_id = ? AND status = ?", followerID, followingID, models.SubscriptionStatusPending)
+	return tx.RowsAffected, tx.Error
+}
+
 func (r *SubscriptionRepository) Delete(id string) error {
 	return r.db.Delete(&models.Subscription{}, "id = ?", id).Error
 }
@@ -63,14 +213,27 @@ func (r *SubscriptionRepository) DeleteByUsers(followerID, followingID string) e
 	return r.db.Delete(&models.Subscription{}, "follower_id = ? AND following_id = ?", followerID, followingID).Error
 }
 
-func (r *SubscriptionRepository) GetFollowersCount(userID string) (int64, error) {
-	var count int64
-	err := r.db.Model(&models.Subscription{}).Where("following_id = ?", userID).Count(&count).Error
-	return count, err
+func (r *SubscriptionRepository) GetFollowCounts(userID string) (*FollowCounts, error) {
+	var counts FollowCounts
+	err := r.db.Model(&models.Subscription{}).
+		Select(`
+			COUNT(*) FILTER (WHERE following_id = ?) AS followers,
+			COUNT(*) FILTER (WHERE follower_id = ?) AS following
+		`, userID, userID).
+		Where("(following_id = ? OR follower_id = ?) AND status = ?", userID, userID, models.SubscriptionStatusAccepted).
+		Scan(&counts).Error
+	return &counts, err
 }
 
-func (r *SubscriptionRepository) GetFollowingCount(userID string) (int64, error) {
-	var count int64
-	err := r.db.Model(&models.Subscription{}).Where("follower_id = ?", userID).Count(&count).Error
-	return count, err
+func (r *SubscriptionRepository) FilterFollowers(followingID string, candidateIDs []string) ([]string, error) {
+	if len(candidateIDs) == 0 {
+		return nil, nil
+	}
+
+	var ids []string
+	err := r.db.Model(&models.Subscription{}).
+		Where("following_id = ? AND follower_id IN ? AND status = ?", followingID, candidateIDs, models.SubscriptionStatusAccepted).
+		Where("NOT EXISTS (SELECT 1 FROM user_mutes WHERE user_mutes.muter_id = subscriptions.follower_id AND user_mutes.muted_id = subscriptions.following_id)").
+		Pluck("follower_id", &ids).Error
+	return ids, err
 }
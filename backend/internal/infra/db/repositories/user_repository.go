@@ -45,21 +45,26 @@ func (r *UserRepository) Delete(id string) error {
 	return r.db.Delete(&models.User{}, "id = ?", id).Error
 }
 
-func (r *UserRepository) Search(query string, limit int) ([]*models.User, error) {
+func (r *UserRepository) Search(query, viewerID string, limit int) ([]*models.User, error) {
 	var users []*models.User
-	err := r.db.Where("display_name ILIKE ?", "%"+query+"%").
+	db := r.db.Where("display_name ILIKE ?", "%"+query+"%")
+	if viewerID != "" {
+		blocked, args := notBlockedCondition("users.id", viewerID)
+		db = db.Where(blocked, args...)
+	}
+	err := db.
 		Limit(limit).
 		Find(&users).Error
 	return users, err
 }
 
-func (r *UserRepository) List(limit, offset int, sortBy, order string) ([]*models.User, error) {
+func (r *UserRepository) List(cursor *Cursor, limit, offset int, sortBy, order string) ([]*models.User, error) {
 	sortColumn := "users.created_at"
 	switch sortBy {
 	case "totalPlaytime":
-		sortColumn = "total_playtime"
+		sortColumn = "COALESCE(SUM(progresses.steam_playtime_forever), 0)::double precision"
 	case "averageRating":
-		sortColumn = "average_rating"
+		sortColumn = "COALESCE(AVG(progresses.rating), 0)::double precision"
 	case "createdAt":
 		sortColumn = "users.created_at"
 	default:
@@ -73,23 +78,39 @@ func (r *UserRepository) List(limit, offset int, sortBy, order string) ([]*model
 
 	type userRow struct {
 		models.User
-		GamesCount     int     `gorm:"column:games_count"`
-		TotalPlaytime  int     `gorm:"column:total_playtime"`
-		AverageRating  float64 `gorm:"column:average_rating"`
+		GamesCount    int     `gorm:"column:games_count"`
+		TotalPlaytime int     `gorm:"column:total_playtime"`
+		AverageRating float64 `gorm:"column:average_rating"`
 	}
 
-	var rows []userRow
-	err := r.db.
+	query := r.db.
 		Table("users").
 		Select(`users.*,
 			COALESCE(COUNT(progresses.id), 0) AS games_count,
 			COALESCE(SUM(progresses.steam_playtime_forever), 0) AS total_playtime,
-			COALESCE(AVG(progresses.rating), 0) AS average_rating`).
+			COALESCE(AVG(progresses.rating), 0)::double precision AS average_rating`).
 		Joins("LEFT JOIN progresses ON progresses.user_id = users.id").
 		Group("users.id").
-		Order(fmt.Sprintf("%s %s", sortColumn, order)).
+		Order(fmt.Sprintf("%s %s, users.id %s", sortColumn, order, order))
+
+	if cursor != nil {
+		comparison := "<"
+		if order == "asc" {
+			comparison = ">"
+		}
+		keyset := fmt.Sprintf("(%s, users.id) %s (?, ?)", sortColumn, comparison)
+		if sortColumn == "users.created_at" {
+			query = query.Where(keyset, cursor.CreatedAt, cursor.ID)
+		} else {
+			query = query.Having(keyset, cursor.Value, cursor.ID)
+		}
+	} else if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	var rows []userRow
+	err := query.
 		Limit(limit).
-		Offset(offset).
 		Scan(&rows).Error
 	if err != nil {
 		return nil, err
@@ -107,6 +128,34 @@ func (r *UserRepository) List(limit, offset int, sortBy, order string) ([]*model
 	return users, nil
 }
 
+func UserCursor(user *models.User, sortBy string) Cursor {
+	cursor := Cursor{ID: user.ID}
+	switch sortBy {
+	case "totalPlaytime":
+		cursor.Value = float64(user.TotalPlaytime)
+	case "averageRating":
+		cursor.Value = user.AverageRating
+	default:
+		cursor.CreatedAt = user.CreatedAt
+	}
+	return cursor
+}
+
+func (r *UserRepository) CanView(ownerID, viewerID string) (bool, error) {
+	condition, args := profileVisibleCondition("users.id", "users.profile_visibility", viewerID)
+	if viewerID != "" {
+		blocked, blockedArgs := notBlockedCondition("users.id", viewerID)
+		condition = condition + " AND " + blocked
+		args = append(args, blockedArgs...)
+	}
+	var exists bool
+	err := r.db.Raw(
+		"SELECT EXISTS(SELECT 1 FROM users WHERE users.id = ? AND "+condition+")",
+		append([]interface{}{ownerID}, args...)...,
+	).Scan(&exists).Error
+	return exists, err
+}
+
 func (r *UserRepository) Count() (int64, error) {
 	var count int64
 	err := r.db.Model(&models.User{}).Count(&count).Error
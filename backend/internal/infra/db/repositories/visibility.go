@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"fmt"
+
+	"gamecheck/internal/domain/models"
+)
+
+func profileVisibleCondition(userIDColumn, visibilityColumn, viewerID string) (string, []interface{}) {
+	if viewerID == "" {
+		return visibilityColumn + " = ?", []interface{}{models.ProfileVisibilityPublic}
+	}
+
+	return fmt.Sprintf(`(%s = ? OR %s = ? OR EXISTS (
+		SELECT 1
+		FROM subscriptions
+		WHERE subscriptions.follower_id = ?
+		  AND subscriptions.following_id = %s
+		  AND subscriptions.status = ?
+	))`, visibilityColumn, userIDColumn, userIDColumn), []interface{}{
+		models.ProfileVisibilityPublic,
+		viewerID,
+		viewerID,
+		models.SubscriptionStatusAccepted,
+	}
+}
+
+func notBlockedCondition(userIDColumn, viewerID string) (string, []interface{}) {
+	return fmt.Sprintf(`NOT EXISTS (
+		SELECT 1
+		FROM user_blocks
+		WHERE (user_blocks.blocker_id = ? AND user_blocks.blocked_id = %s)
+		   OR (user_blocks.blocker_id = %s AND user_blocks.blocked_id = ?)
+	)`, userIDColumn, userIDColumn), []interface{}{viewerID, viewerID}
+}
+
+func entryVisibleCondition(userIDColumn, visibilityColumn, viewerID string) (string, []interface{}) {
+	if viewerID == "" {
+		return visibilityColumn + " = ?", []interface{}{models.ProfileVisibilityPublic}
+	}
+
+	return fmt.Sprintf(`(%s = ? OR %s = ? OR (%s = ? AND EXISTS (
+		SELECT 1
+		FROM subscriptions
+		WHERE subscriptions.follower_id = ?
+		  AND subscriptions.following_id = %s
+		  AND subscriptions.status = ?
+	)))`, visibilityColumn, userIDColumn, visibilityColumn, userIDColumn), []interface{}{
+		models.ProfileVisibilityPublic,
+		viewerID,
+		models.ProfileVisibilityFollowers,
+		viewerID,
+		models.SubscriptionStatusAccepted,
+	}
+}
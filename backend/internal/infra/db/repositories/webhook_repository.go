@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"time"
+
+	"gamecheck/internal/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *WebhookRepository) Update(webhook *models.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+func (r *WebhookRepository) Delete(id string) error {
+	return r.db.Delete(&models.Webhook{}, "id = ?", id).Error
+}
+
+func (r *WebhookRepository) GetByID(id string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := r.db.First(&webhook, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) ListByUserID(userID string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *WebhookRepository) CountByUserID(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Webhook{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *WebhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+func (r *WebhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Save(delivery).Error
+}
+
+func (r *WebhookRepository) GetDelivery(id, webhookID string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.First(&delivery, "id = ? AND webhook_id = ?", id, webhookID).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepository) ListDeliveries(webhookID, status string, limit, offset int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	query := r.db.Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *WebhookRepository) CountDeliveries(webhookID, status string) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+func (r *WebhookRepository) ClaimDueDeliveries(limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Raw(
+		`UPDATE webhook_deliveries
+		SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = ?
+			  AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		time.Now().Add(lease),
+		models.WebhookDeliveryPending,
+		limit,
+	).Scan(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *WebhookRepository) PurgeDeliveredBefore(cutoff time.Time) (int64, error) {
+	tx := r.db.
+		Where("status = ? AND delivered_at < ?", models.WebhookDeliveryDelivered, cutoff).
+		Delete(&models.WebhookDelivery{})
+	return tx.RowsAffected, tx.Error
+}
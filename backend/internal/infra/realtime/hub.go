@@ -0,0 +1,129 @@
+package realtime
+
+import "sync"
+
+const clientBufferSize = 32
+
+type Event struct {
+	ID   string
+	Type string
+	Data interface{}
+}
+
+type Client struct {
+	UserID string
+	events chan Event
+}
+
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[string]map[*Client]struct{}
+	closed  bool
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		clients: make(map[string]map[*Client]struct{}),
+	}
+}
+
+func (h *Hub) Subscribe(userID string) *Client {
+	client := &Client{
+		UserID: userID,
+		events: make(chan Event, clientBufferSize),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(client.events)
+		return client
+	}
+
+	if h.clients[userID] == nil {
+		h.clients[userID] = make(map[*Client]struct{})
+	}
+	h.clients[userID][client] = struct{}{}
+	return client
+}
+
+func (h *Hub) Unsubscribe(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(client)
+}
+
+func (h *Hub) Publish(userID string, event Event) {
+	var overflowed []*Client
+
+	h.mu.RLock()
+	for client := range h.clients[userID] {
+		select {
+		case client.events <- event:
+		default:
+			overflowed = append(overflowed, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(overflowed) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	for _, client := range overflowed {
+		h.remove(client)
+	}
+	h.mu.Unlock()
+}
+
+func (h *Hub) IsConnected(userID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients[userID]) > 0
+}
+
+func (h *Hub) ConnectedUserIDs() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ids := make([]string, 0, len(h.clients))
+	for id := range h.clients {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, clients := range h.clients {
+		for client := range clients {
+			close(client.events)
+		}
+	}
+	h.clients = make(map[string]map[*Client]struct{})
+	h.closed = true
+}
+
+func (h *Hub) remove(client *Client) {
+	clients, ok := h.clients[client.UserID]
+	if !ok {
+		return
+	}
+	if _, ok := clients[client]; !ok {
+		return
+	}
+
+	delete(clients, client)
+	close(client.events)
+	if len(clients) == 0 {
+		delete(h.clients, client.UserID)
+	}
+}
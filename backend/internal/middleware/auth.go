@@ -54,6 +54,50 @@ func OptionalAuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 	}
 }
 
+func StreamAuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if token == "" {
+			token = ctx.Query("token")
+		}
+		if token == "" {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
+			ctx.Abort()
+			return
+		}
+
+		userID, err := authService.ValidateJWT(token)
+		if err != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set("userID", userID)
+		ctx.Next()
+	}
+}
+
+func AdminMiddleware(authService *services.AuthService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, err := GetUserID(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+			ctx.Abort()
+			return
+		}
+
+		user, err := authService.GetUserByID(userID)
+		if err != nil || user == nil || !user.IsAdmin {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
 func ErrorHandler() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		ctx.Next()
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"gamecheck/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ProfileAccessMiddleware(userService *services.UserService, param string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		viewerID, _ := GetUserID(ctx)
+
+		allowed, err := userService.CanViewProfile(ctx.Param(param), viewerID)
+		if err != nil {
+			if errors.Is(err, services.ErrUserNotFound) {
+				ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			} else {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check profile access"})
+			}
+			ctx.Abort()
+			return
+		}
+
+		if !allowed {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "profile is private"})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -1,12 +1,29 @@
 package services
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"gamecheck/internal/domain/models"
 	"gamecheck/internal/infra/db/repositories"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	feedAggregationWindow  = time.Hour
+	feedAggregationSample  = 5
+	feedAggregationMaxSize = 100
+	feedAggregationBatch   = 50
+)
+
+var (
+	ErrActivityNotFound     = errors.New("activity not found")
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrCommentForbidden     = errors.New("not allowed to modify this comment")
+	ErrInvalidParentComment = errors.New("invalid parent comment")
 )
 
 type ActivityUser struct {
@@ -21,57 +38,194 @@ type ActivityProgress struct {
 }
 
 type ActivityResponse struct {
-	ID           string              `json:"id"`
-	Type         models.ActivityType `json:"type"`
-	UserID       string              `json:"userId"`
-	User         ActivityUser        `json:"user"`
-	ProgressID   *string             `json:"progressId,omitempty"`
-	Progress     *ActivityProgress   `json:"progress,omitempty"`
-	GameName     *string             `json:"gameName,omitempty"`
-	Status       *models.GameStatus  `json:"status,omitempty"`
-	Rating       *int                `json:"rating,omitempty"`
-	TargetUserID *string             `json:"targetUserId,omitempty"`
-	TargetUser   *ActivityUser       `json:"targetUser,omitempty"`
-	CreatedAt    time.Time           `json:"createdAt"`
+	ID            string              `json:"id"`
+	Type          models.ActivityType `json:"type"`
+	UserID        string              `json:"userId"`
+	User          ActivityUser        `json:"user"`
+	ProgressID    *string             `json:"progressId,omitempty"`
+	Progress      *ActivityProgress   `json:"progress,omitempty"`
+	GameName      *string             `json:"gameName,omitempty"`
+	Status        *models.GameStatus  `json:"status,omitempty"`
+	Rating        *int                `json:"rating,omitempty"`
+	TargetUserID  *string             `json:"targetUserId,omitempty"`
+	TargetUser    *ActivityUser       `json:"targetUser,omitempty"`
+	LikesCount    int                 `json:"likesCount"`
+	CommentsCount int                 `json:"commentsCount"`
+	LikedByViewer bool                `json:"likedByViewer"`
+	Group         *ActivityGroup      `json:"group,omitempty"`
+	CreatedAt     time.Time           `json:"createdAt"`
+}
+
+type ActivityGroup struct {
+	Count     int                 `json:"count"`
+	MemberIDs []string            `json:"memberIds"`
+	Sample    []*ActivityResponse `json:"sample"`
+	StartedAt time.Time           `json:"startedAt"`
+}
+
+type ActivityLikeResponse struct {
+	ActivityID    string `json:"activityId"`
+	LikesCount    int64  `json:"likesCount"`
+	LikedByViewer bool   `json:"likedByViewer"`
+}
+
+type ActivityCommentResponse struct {
+	ID         string                     `json:"id"`
+	ActivityID string                     `json:"activityId"`
+	ParentID   *string                    `json:"parentId,omitempty"`
+	User       ActivityUser               `json:"user"`
+	Body       string                     `json:"body"`
+	EditedAt   *time.Time                 `json:"editedAt,omitempty"`
+	CreatedAt  time.Time                  `json:"createdAt"`
+	CanEdit    bool                       `json:"canEdit"`
+	CanDelete  bool                       `json:"canDelete"`
+	Replies    []*ActivityCommentResponse `json:"replies"`
+}
+
+type ActivityCommentsPage struct {
+	Data   []*ActivityCommentResponse `json:"data"`
+	Total  int                        `json:"total"`
+	Limit  int                        `json:"limit"`
+	Offset int                        `json:"offset"`
+}
+
+type ActivityPageResponse struct {
+	Data       []*ActivityResponse `json:"data"`
+	Limit      int                 `json:"limit"`
+	Offset     int                 `json:"offset"`
+	NextCursor *string             `json:"nextCursor"`
 }
 
 type ActivityService struct {
-	activityRepository *repositories.ActivityRepository
-	userRepository     *repositories.UserRepository
+	activityRepository  *repositories.ActivityRepository
+	userRepository      *repositories.UserRepository
+	commentRepository   *repositories.ActivityCommentRepository
+	notificationService *NotificationService
 }
 
 func NewActivityService(
 	activityRepo *repositories.ActivityRepository,
 	userRepo *repositories.UserRepository,
+	commentRepo *repositories.ActivityCommentRepository,
+	notificationService *NotificationService,
 ) *ActivityService {
 	return &ActivityService{
-		activityRepository: activityRepo,
-		userRepository:     userRepo,
+		activityRepository:  activityRepo,
+		userRepository:      userRepo,
+		commentRepository:   commentRepo,
+		notificationService: notificationService,
 	}
 }
 
-func (s *ActivityService) GetFeed(userID string, limit, offset int) ([]*ActivityResponse, error) {
-	rows, err := s.activityRepository.GetFeedRows(userID, limit, offset)
+func (s *ActivityService) GetFeed(userID string, cursor *repositories.Cursor, limit, offset int, aggregate bool) (*ActivityPageResponse, error) {
+	if aggregate {
+		return s.getAggregatedFeed(userID, cursor, limit, offset)
+	}
+
+	rows, err := s.activityRepository.GetFeedRows(userID, cursor, limit+1, offset)
 	if err != nil {
 		return nil, err
 	}
-	return mapActivityRows(rows), nil
+	return buildActivityPage(rows, limit, offset), nil
 }
 
-func (s *ActivityService) GetAllActivities(limit, offset int) ([]*ActivityResponse, error) {
-	rows, err := s.activityRepository.GetAllRows(limit, offset)
+func (s *ActivityService) getAggregatedFeed(userID string, cursor *repositories.Cursor, limit, offset int) (*ActivityPageResponse, error) {
+	var groups [][]repositories.ActivityRow
+	for len(groups) <= offset+limit {
+		rows, err := s.activityRepository.GetFeedRows(userID, cursor, feedAggregationBatch, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			last := len(groups) - 1
+			if last >= 0 && canAggregateActivity(groups[last], row) {
+				groups[last] = append(groups[last], row)
+				continue
+			}
+			groups = append(groups, []repositories.ActivityRow{row})
+		}
+
+		if len(rows) < feedAggregationBatch {
+			break
+		}
+		tail := rows[len(rows)-1]
+		cursor = &repositories.Cursor{CreatedAt: tail.CreatedAt, ID: tail.ID}
+	}
+
+	page := &ActivityPageResponse{Limit: limit, Offset: offset}
+	if offset >= len(groups) {
+		groups = nil
+	} else {
+		groups = groups[offset:]
+	}
+	if len(groups) > limit {
+		groups = groups[:limit]
+		members := groups[len(groups)-1]
+		tail := members[len(members)-1]
+		next := repositories.EncodeCursor(repositories.Cursor{CreatedAt: tail.CreatedAt, ID: tail.ID})
+		page.NextCursor = &next
+	}
+
+	page.Data = make([]*ActivityResponse, 0, len(groups))
+	for _, members := range groups {
+		page.Data = append(page.Data, mapActivityGroup(members))
+	}
+	return page, nil
+}
+
+func canAggregateActivity(group []repositories.ActivityRow, row repositories.ActivityRow) bool {
+	head := group[0]
+	if len(group) >= feedAggregationMaxSize {
+		return false
+	}
+	if head.UserID != row.UserID || head.Type != row.Type {
+		return false
+	}
+	if head.Type == models.ActivityTypeUpdateStatus {
+		if head.Status == nil || row.Status == nil || *head.Status != *row.Status {
+			return false
+		}
+	}
+	return head.CreatedAt.Sub(row.CreatedAt) <= feedAggregationWindow
+}
+
+func mapActivityGroup(members []repositories.ActivityRow) *ActivityResponse {
+	response := mapActivityRow(&members[0])
+	if len(members) == 1 {
+		return response
+	}
+
+	group := &ActivityGroup{
+		Count:     len(members),
+		MemberIDs: make([]string, 0, len(members)),
+		Sample:    make([]*ActivityResponse, 0, feedAggregationSample),
+		StartedAt: members[len(members)-1].CreatedAt,
+	}
+	for i := range members {
+		group.MemberIDs = append(group.MemberIDs, members[i].ID)
+		if len(group.Sample) < feedAggregationSample {
+			group.Sample = append(group.Sample, mapActivityRow(&members[i]))
+		}
+	}
+	response.Group = group
+	return response
+}
+
+func (s *ActivityService) GetAllActivities(viewerID string, cursor *repositories.Cursor, limit, offset int) (*ActivityPageResponse, error) {
+	rows, err := s.activityRepository.GetAllRows(viewerID, cursor, limit+1, offset)
 	if err != nil {
 		return nil, err
 	}
-	return mapActivityRows(rows), nil
+	return buildActivityPage(rows, limit, offset), nil
 }
 
-func (s *ActivityService) GetUserActivity(userID string, limit, offset int) ([]*ActivityResponse, error) {
-	rows, err := s.activityRepository.GetByUserIDRows(userID, limit, offset)
+func (s *ActivityService) GetUserActivity(userID, viewerID string, cursor *repositories.Cursor, limit, offset int) (*ActivityPageResponse, error) {
+	rows, err := s.activityRepository.GetByUserIDRows(userID, viewerID, cursor, limit+1, offset)
 	if err != nil {
 		return nil, err
 	}
-	return mapActivityRows(rows), nil
+	return buildActivityPage(rows, limit, offset), nil
 }
 
 func (s *ActivityService) Follow(followerID, followingID string) (*models.Activity, error) {
@@ -93,6 +247,313 @@ func (s *ActivityService) Unfollow(followerID, followingID string) error {
 	return nil
 }
 
+func (s *ActivityService) GetActivity(id, viewerID string) (*ActivityResponse, error) {
+	row, err := s.activityRepository.GetRowByID(id, viewerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrActivityNotFound
+		}
+		return nil, err
+	}
+
+	visible, err := s.userRepository.CanView(row.UserID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrActivityNotFound
+	}
+	return mapActivityRow(row), nil
+}
+
+func (s *ActivityService) Like(activityID, userID string) (*ActivityLikeResponse, error) {
+	activity, err := s.getVisibleActivity(activityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.commentRepository.AddLike(activityID, userID); err != nil {
+		return nil, err
+	}
+
+	s.notificationService.Dispatch(NotificationEvent{
+		Type:        models.NotificationTypeActivityLike,
+		ActorID:     userID,
+		RecipientID: activity.UserID,
+		ActivityID:  &activity.ID,
+		GameName:    activity.GameName,
+	})
+
+	return s.likeResponse(activityID, true)
+}
+
+func (s *ActivityService) Unlike(activityID, userID string) (*ActivityLikeResponse, error) {
+	if _, err := s.getActivity(activityID); err != nil {
+		return nil, err
+	}
+	if err := s.commentRepository.RemoveLike(activityID, userID); err != nil {
+		return nil, err
+	}
+	return s.likeResponse(activityID, false)
+}
+
+func (s *ActivityService) GetComments(activityID, viewerID string, limit, offset int) (*ActivityCommentsPage, error) {
+	activity, err := s.getVisibleActivity(activityID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.commentRepository.CountRootsByActivityID(activityID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.commentRepository.ListRootsByActivityID(activityID, viewerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	rootIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		rootIDs = append(rootIDs, row.ID)
+	}
+	replies, err := s.commentRepository.ListRepliesByRootIDs(rootIDs, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ActivityCommentsPage{
+		Data:   buildCommentTree(append(rows, replies...), activity.UserID, viewerID),
+		Total:  int(total),
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	return page, nil
+}
+
+func (s *ActivityService) AddComment(activityID, userID, body string, parentID *string) (*ActivityCommentResponse, error) {
+	activity, err := s.getVisibleActivity(activityID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *models.ActivityComment
+	if parentID != nil {
+		parent, err = s.commentRepository.GetByID(*parentID)
+		if err != nil || parent.ActivityID != activityID {
+			return nil, ErrInvalidParentComment
+		}
+	}
+
+	comment := &models.ActivityComment{
+		ID:         uuid.New().String(),
+		ActivityID: activityID,
+		UserID:     userID,
+		ParentID:   parentID,
+		Body:       strings.TrimSpace(body),
+	}
+	if err := s.commentRepository.Create(comment); err != nil {
+		return nil, err
+	}
+
+	s.notificationService.Dispatch(NotificationEvent{
+		Type:        models.NotificationTypeActivityComment,
+		ActorID:     userID,
+		RecipientID: activity.UserID,
+		ActivityID:  &activity.ID,
+		CommentID:   &comment.ID,
+		GameName:    activity.GameName,
+	})
+	if parent != nil && parent.UserID != activity.UserID {
+		s.notificationService.Dispatch(NotificationEvent{
+			Type:        models.NotificationTypeCommentReply,
+			ActorID:     userID,
+			RecipientID: parent.UserID,
+			ActivityID:  &activity.ID,
+			CommentID:   &comment.ID,
+			GameName:    activity.GameName,
+		})
+	}
+
+	return s.getCommentView(comment.ID, activity.UserID, userID)
+}
+
+func (s *ActivityService) EditComment(commentID, userID, body string) (*ActivityCommentResponse, error) {
+	comment, err := s.getComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != userID {
+		return nil, ErrCommentForbidden
+	}
+
+	if err := s.commentRepository.UpdateBody(comment.ID, strings.TrimSpace(body)); err != nil {
+		return nil, err
+	}
+
+	activity, err := s.getActivity(comment.ActivityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getCommentView(comment.ID, activity.UserID, userID)
+}
+
+func (s *ActivityService) DeleteComment(commentID, userID string) error {
+	comment, err := s.getComment(commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != userID {
+		activity, err := s.getActivity(comment.ActivityID)
+		if err != nil {
+			return err
+		}
+		if activity.UserID != userID {
+			return ErrCommentForbidden
+		}
+	}
+
+	return s.commentRepository.Delete(comment.ID)
+}
+
+func (s *ActivityService) getActivity(id string) (*models.Activity, error) {
+	activity, err := s.activityRepository.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrActivityNotFound
+		}
+		return nil, err
+	}
+	return activity, nil
+}
+
+func (s *ActivityService) getVisibleActivity(id, viewerID string) (*models.Activity, error) {
+	activity, err := s.getActivity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := s.userRepository.CanView(activity.UserID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrActivityNotFound
+	}
+	if activity.ProgressID != nil {
+		if _, err := s.activityRepository.GetRowByID(id, viewerID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrActivityNotFound
+			}
+			return nil, err
+		}
+	}
+	return activity, nil
+}
+
+func (s *ActivityService) getComment(id string) (*models.ActivityComment, error) {
+	comment, err := s.commentRepository.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *ActivityService) getCommentView(commentID, activityOwnerID, viewerID string) (*ActivityCommentResponse, error) {
+	comment, err := s.commentRepository.GetByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepository.GetByID(comment.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapActivityComment(&repositories.ActivityCommentRow{
+		ID:              comment.ID,
+		ActivityID:      comment.ActivityID,
+		ParentID:        comment.ParentID,
+		UserID:          comment.UserID,
+		UserDisplayName: user.DisplayName,
+		UserAvatarURL:   user.AvatarURL,
+		Body:            comment.Body,
+		EditedAt:        comment.EditedAt,
+		CreatedAt:       comment.CreatedAt,
+	}, activityOwnerID, viewerID), nil
+}
+
+func (s *ActivityService) likeResponse(activityID string, liked bool) (*ActivityLikeResponse, error) {
+	count, err := s.commentRepository.CountLikes(activityID)
+	if err != nil {
+		return nil, err
+	}
+	return &ActivityLikeResponse{
+		ActivityID:    activityID,
+		LikesCount:    count,
+		LikedByViewer: liked,
+	}, nil
+}
+
+func buildCommentTree(rows []repositories.ActivityCommentRow, activityOwnerID, viewerID string) []*ActivityCommentResponse {
+	byID := make(map[string]*ActivityCommentResponse, len(rows))
+	for i := range rows {
+		byID[rows[i].ID] = mapActivityComment(&rows[i], activityOwnerID, viewerID)
+	}
+
+	roots := make([]*ActivityCommentResponse, 0)
+	for i := range rows {
+		comment := byID[rows[i].ID]
+		if comment.ParentID != nil {
+			if parent, ok := byID[*comment.ParentID]; ok {
+				parent.Replies = append(parent.Replies, comment)
+				continue
+			}
+		}
+		roots = append(roots, comment)
+	}
+	return roots
+}
+
+func mapActivityComment(row *repositories.ActivityCommentRow, activityOwnerID, viewerID string) *ActivityCommentResponse {
+	isAuthor := viewerID != "" && row.UserID == viewerID
+	return &ActivityCommentResponse{
+		ID:         row.ID,
+		ActivityID: row.ActivityID,
+		ParentID:   row.ParentID,
+		User: ActivityUser{
+			ID:          row.UserID,
+			DisplayName: row.UserDisplayName,
+			AvatarURL:   row.UserAvatarURL,
+		},
+		Body:      row.Body,
+		EditedAt:  row.EditedAt,
+		CreatedAt: row.CreatedAt,
+		CanEdit:   isAuthor,
+		CanDelete: isAuthor || (viewerID != "" && activityOwnerID == viewerID),
+		Replies:   []*ActivityCommentResponse{},
+	}
+}
+
+func buildActivityPage(rows []repositories.ActivityRow, limit, offset int) *ActivityPageResponse {
+	page := &ActivityPageResponse{
+		Limit:  limit,
+		Offset: offset,
+	}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[len(rows)-1]
+		next := repositories.EncodeCursor(repositories.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		page.NextCursor = &next
+	}
+	page.Data = mapActivityRows(rows)
+	return page
+}
+
 func mapActivityRows(rows []repositories.ActivityRow) []*ActivityResponse {
 	results := make([]*ActivityResponse, 0, len(rows))
 	for i := range rows {
@@ -107,15 +568,18 @@ func mapActivityRow(row *repositories.ActivityRow) *ActivityResponse {
 	}
 
 	resp := &ActivityResponse{
-		ID:           row.ID,
-		Type:         row.Type,
-		UserID:       row.UserID,
-		ProgressID:   row.ProgressID,
-		GameName:     row.GameName,
-		Status:       row.Status,
-		Rating:       row.Rating,
-		TargetUserID: row.TargetUserID,
-		CreatedAt:    row.CreatedAt,
+		ID:            row.ID,
+		Type:          row.Type,
+		UserID:        row.UserID,
+		ProgressID:    row.ProgressID,
+		GameName:      row.GameName,
+		Status:        row.Status,
+		Rating:        row.Rating,
+		TargetUserID:  row.TargetUserID,
+		LikesCount:    row.LikesCount,
+		CommentsCount: row.CommentsCount,
+		LikedByViewer: row.LikedByViewer,
+		CreatedAt:     row.CreatedAt,
 		User: ActivityUser{
 			ID:          row.UserID,
 			DisplayName: row.UserDisplayName,
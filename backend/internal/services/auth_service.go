@@ -93,6 +93,7 @@ func (s *AuthService) HandleSteamCallback(steamID string) (string, *models.User,
 			AvatarURL:   avatarURL,
 			ProfileURL:  profileURL,
 			ShowWelcome: true,
+			IsAdmin:     s.config.IsAdminSteamID(steamID),
 		}
 		if err := s.userRepository.Create(user); err != nil {
 			return "", nil, fmt.Errorf("failed to create user: %w", err)
@@ -101,6 +102,9 @@ func (s *AuthService) HandleSteamCallback(steamID string) (string, *models.User,
 		user.DisplayName = displayName
 		user.AvatarURL = avatarURL
 		user.ProfileURL = profileURL
+		if s.config.IsAdminSteamID(steamID) {
+			user.IsAdmin = true
+		}
 		user.UpdateLastLogin()
 		if err := s.userRepository.Update(user); err != nil {
 			return "", nil, fmt.Errorf("failed to update user: %w", err)
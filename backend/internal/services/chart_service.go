@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+)
+
+const (
+	chartRefreshEvery  = 30 * time.Minute
+	chartBayesianPrior = 5.0
+)
+
+var (
+	ErrInvalidChart       = errors.New("invalid chart")
+	ErrInvalidChartWindow = errors.New("invalid chart window")
+)
+
+var chartWindows = map[models.ChartType][]models.ChartWindow{
+	models.ChartTypeTrending:    {models.ChartWindowWeek, models.ChartWindowMonth},
+	models.ChartTypeTopRated:    {models.ChartWindowWeek, models.ChartWindowMonth, models.ChartWindowAll},
+	models.ChartTypeMostDropped: {models.ChartWindowWeek, models.ChartWindowMonth},
+}
+
+var chartDefaultWindows = map[models.ChartType]models.ChartWindow{
+	models.ChartTypeTrending:    models.ChartWindowWeek,
+	models.ChartTypeTopRated:    models.ChartWindowAll,
+	models.ChartTypeMostDropped: models.ChartWindowMonth,
+}
+
+type ChartEntryResponse struct {
+	Rank          int      `json:"rank"`
+	ID            string   `json:"id"`
+	SteamAppID    *int     `json:"steamAppId,omitempty"`
+	Name          string   `json:"name"`
+	HeaderImage   string   `json:"headerImage,omitempty"`
+	CapsuleImage  string   `json:"capsuleImage,omitempty"`
+	PrimaryGenre  string   `json:"primaryGenre,omitempty"`
+	Genres        []string `json:"genres"`
+	Score         float64  `json:"score"`
+	EventCount    int      `json:"eventCount"`
+	AverageRating *float64 `json:"averageRating,omitempty"`
+}
+
+type ChartPage struct {
+	Chart      models.ChartType      `json:"chart"`
+	Window     models.ChartWindow    `json:"window"`
+	Genre      string                `json:"genre,omitempty"`
+	Data       []*ChartEntryResponse `json:"data"`
+	Total      int64                 `json:"total"`
+	Limit      int                   `json:"limit"`
+	Offset     int                   `json:"offset"`
+	ComputedAt *time.Time            `json:"computedAt,omitempty"`
+}
+
+type ChartService struct {
+	chartRepository *repositories.ChartRepository
+}
+
+func NewChartService(chartRepo *repositories.ChartRepository) *ChartService {
+	return &ChartService{chartRepository: chartRepo}
+}
+
+func (s *ChartService) GetChart(chartType models.ChartType, window models.ChartWindow, genre string, limit, offset int) (*ChartPage, error) {
+	windows, ok := chartWindows[chartType]
+	if !ok {
+		return nil, ErrInvalidChart
+	}
+	if window == "" {
+		window = chartDefaultWindows[chartType]
+	}
+	if !containsChartWindow(windows, window) {
+		return nil, ErrInvalidChartWindow
+	}
+
+	rows, err := s.chartRepository.ListChart(chartType, window, genre, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.chartRepository.CountChart(chartType, window, genre)
+	if err != nil {
+		return nil, err
+	}
+	computedAt, err := s.chartRepository.GetComputedAt(chartType, window)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ChartPage{
+		Chart:      chartType,
+		Window:     window,
+		Genre:      genre,
+		Data:       make([]*ChartEntryResponse, 0, len(rows)),
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+		ComputedAt: computedAt,
+	}
+	for _, row := range rows {
+		page.Data = append(page.Data, &ChartEntryResponse{
+			Rank:          row.Rank,
+			ID:            row.ID,
+			SteamAppID:    row.SteamAppID,
+			Name:          row.Name,
+			HeaderImage:   row.HeaderImage,
+			CapsuleImage:  row.CapsuleImage,
+			PrimaryGenre:  row.PrimaryGenre,
+			Genres:        row.Genres,
+			Score:         row.Score,
+			EventCount:    row.EventCount,
+			AverageRating: row.AverageRating,
+		})
+	}
+	return page, nil
+}
+
+func (s *ChartService) Run(ctx context.Context) {
+	s.RefreshAll()
+
+	ticker := time.NewTicker(chartRefreshEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RefreshAll()
+		}
+	}
+}
+
+func (s *ChartService) RefreshAll() {
+	now := time.Now()
+	for chartType, windows := range chartWindows {
+		for _, window := range windows {
+			if err := s.refresh(chartType, window, now); err != nil {
+				log.Printf("failed to refresh %s chart for %s: %v", chartType, window, err)
+			}
+		}
+	}
+}
+
+func (s *ChartService) refresh(chartType models.ChartType, window models.ChartWindow, now time.Time) error {
+	since := chartWindowStart(window, now)
+
+	var (
+		rows []repositories.ChartScoreRow
+		err  error
+	)
+	switch chartType {
+	case models.ChartTypeTrending:
+		rows, err = s.chartRepository.ComputeTrending(*since)
+	case models.ChartTypeMostDropped:
+		rows, err = s.chartRepository.ComputeMostDropped(*since)
+	case models.ChartTypeTopRated:
+		rows, err = s.chartRepository.ComputeTopRated(since, chartBayesianPrior)
+	default:
+		return ErrInvalidChart
+	}
+	if err != nil {
+		return err
+	}
+
+	entries := make([]*models.GameChartEntry, 0, len(rows))
+	for _, row := range rows {
+		var averageRating *float64
+		if row.AverageRating != nil {
+			rounded := math.Round(*row.AverageRating*100) / 100
+			averageRating = &rounded
+		}
+		entries = append(entries, &models.GameChartEntry{
+			ChartType:     chartType,
+			Window:        window,
+			GameID:        row.GameID,
+			Score:         math.Round(row.Score*1000) / 1000,
+			EventCount:    row.EventCount,
+			AverageRating: averageRating,
+			ComputedAt:    now,
+		})
+	}
+	return s.chartRepository.ReplaceChart(chartType, window, entries)
+}
+
+func chartWindowStart(window models.ChartWindow, now time.Time) *time.Time {
+	var since time.Time
+	switch window {
+	case models.ChartWindowWeek:
+		since = now.AddDate(0, 0, -7)
+	case models.ChartWindowMonth:
+		since = now.AddDate(0, 0, -30)
+	default:
+		return nil
+	}
+	return &since
+}
+
+func containsChartWindow(windows []models.ChartWindow, window models.ChartWindow) bool {
+	for _, candidate := range windows {
+		if candidate == window {
+			return true
+		}
+	}
+	return false
+}
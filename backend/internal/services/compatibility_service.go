@@ -0,0 +1,209 @@
+package services
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+)
+
+const (
+	compatibilityCacheTTL        = 15 * time.Minute
+	compatibilityCacheMaxEntries = 1000
+	compatibilityMinCorrelated   = 3
+	compatibilityRatingRange     = 9
+)
+
+type CompatibilityGame struct {
+	SteamAppID  int               `json:"steamAppId"`
+	Name        string            `json:"name"`
+	UserStatus  models.GameStatus `json:"userStatus"`
+	UserRating  *int              `json:"userRating,omitempty"`
+	OtherStatus models.GameStatus `json:"otherStatus"`
+	OtherRating *int              `json:"otherRating,omitempty"`
+}
+
+type CompatibilityResponse struct {
+	UserID            string              `json:"userId"`
+	OtherUserID       string              `json:"otherUserId"`
+	Score             int                 `json:"score"`
+	SharedCount       int                 `json:"sharedCount"`
+	RatedBothCount    int                 `json:"ratedBothCount"`
+	RatingCorrelation *float64            `json:"ratingCorrelation"`
+	SharedGames       []CompatibilityGame `json:"sharedGames"`
+	SuggestedForOther []CompatibilityGame `json:"suggestedForOther"`
+	SuggestedForUser  []CompatibilityGame `json:"suggestedForUser"`
+	ComputedAt        time.Time           `json:"computedAt"`
+}
+
+type compatibilityCacheEntry struct {
+	result    *CompatibilityResponse
+	expiresAt time.Time
+}
+
+type CompatibilityService struct {
+	progressRepository *repositories.ProgressRepository
+	cache              map[string]compatibilityCacheEntry
+	cacheMu            sync.RWMutex
+}
+
+func NewCompatibilityService(progressRepo *repositories.ProgressRepository) *CompatibilityService {
+	return &CompatibilityService{
+		progressRepository: progressRepo,
+		cache:              make(map[string]compatibilityCacheEntry),
+	}
+}
+
+func (s *CompatibilityService) Compare(userID, otherID string) (*CompatibilityResponse, error) {
+	key := userID + ":" + otherID
+	if cached, ok := s.getCached(key); ok {
+		return cached, nil
+	}
+
+	rows, err := s.progressRepository.ListSharedGames(userID, otherID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.progressRepository.CountSteamGamesByUserIDs([]string{userID, otherID})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CompatibilityResponse{
+		UserID:            userID,
+		OtherUserID:       otherID,
+		SharedCount:       len(rows),
+		SharedGames:       make([]CompatibilityGame, 0, len(rows)),
+		SuggestedForOther: []CompatibilityGame{},
+		SuggestedForUser:  []CompatibilityGame{},
+		ComputedAt:        time.Now(),
+	}
+
+	var userRatings, otherRatings []float64
+	for _, row := range rows {
+		game := CompatibilityGame{
+			SteamAppID:  row.SteamAppID,
+			Name:        row.Name,
+			UserStatus:  row.UserStatus,
+			UserRating:  row.UserRating,
+			OtherStatus: row.OtherStatus,
+			OtherRating: row.OtherRating,
+		}
+		result.SharedGames = append(result.SharedGames, game)
+
+		if row.UserRating != nil && row.OtherRating != nil {
+			userRatings = append(userRatings, float64(*row.UserRating))
+			otherRatings = append(otherRatings, float64(*row.OtherRating))
+		}
+		if row.UserStatus == models.GameStatusCompleted && row.OtherStatus == models.GameStatusPlanToPlay {
+			result.SuggestedForOther = append(result.SuggestedForOther, game)
+		}
+		if row.OtherStatus == models.GameStatusCompleted && row.UserStatus == models.GameStatusPlanToPlay {
+			result.SuggestedForUser = append(result.SuggestedForUser, game)
+		}
+	}
+
+	result.RatedBothCount = len(userRatings)
+	if len(userRatings) >= compatibilityMinCorrelated {
+		result.RatingCorrelation = pearsonCorrelation(userRatings, otherRatings)
+	}
+	result.Score = compatibilityScore(len(rows), counts[userID], counts[otherID], userRatings, otherRatings, result.RatingCorrelation)
+
+	s.setCached(key, result)
+	return result, nil
+}
+
+func compatibilityScore(shared int, userTotal, otherTotal int64, userRatings, otherRatings []float64, correlation *float64) int {
+	smaller := userTotal
+	if otherTotal < smaller {
+		smaller = otherTotal
+	}
+	if smaller == 0 {
+		return 0
+	}
+	overlap := math.Min(1, float64(shared)/float64(smaller))
+
+	agreement := -1.0
+	if correlation != nil {
+		agreement = (*correlation + 1) / 2
+	} else if len(userRatings) > 0 {
+		var diff float64
+		for i := range userRatings {
+			diff += math.Abs(userRatings[i] - otherRatings[i])
+		}
+		agreement = 1 - diff/float64(len(userRatings))/compatibilityRatingRange
+	}
+
+	score := overlap
+	if agreement >= 0 {
+		score = 0.4*overlap + 0.6*agreement
+	}
+	return int(math.Round(score * 100))
+}
+
+func pearsonCorrelation(xs, ys []float64) *float64 {
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return nil
+	}
+
+	value := math.Round(cov/math.Sqrt(varX*varY)*1000) / 1000
+	return &value
+}
+
+func (s *CompatibilityService) getCached(key string) (*CompatibilityResponse, bool) {
+	s.cacheMu.RLock()
+	entry, exists := s.cache[key]
+	s.cacheMu.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		s.cacheMu.Lock()
+		delete(s.cache, key)
+		s.cacheMu.Unlock()
+		return nil, false
+	}
+
+	return entry.result, true
+}
+
+func (s *CompatibilityService) setCached(key string, result *CompatibilityResponse) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if len(s.cache) >= compatibilityCacheMaxEntries {
+		now := time.Now()
+		for cacheKey, entry := range s.cache {
+			if now.After(entry.expiresAt) {
+				delete(s.cache, cacheKey)
+			}
+		}
+		if len(s.cache) >= compatibilityCacheMaxEntries {
+			s.cache = make(map[string]compatibilityCacheEntry)
+		}
+	}
+
+	s.cache[key] = compatibilityCacheEntry{
+		result:    result,
+		expiresAt: time.Now().Add(compatibilityCacheTTL),
+	}
+}
@@ -1,11 +1,15 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
 	"log"
+	"math"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"gamecheck/internal/domain/models"
 	"gamecheck/internal/infra/db/repositories"
@@ -23,7 +27,65 @@ type LibraryGameResponse struct {
 
 type LibraryGameDetailResponse struct {
 	LibraryGameResponse
-	Comments []repositories.LibraryComment `json:"comments"`
+	RedirectedFrom string                        `json:"redirectedFrom,omitempty"`
+	Series         []SeriesPlacement             `json:"series"`
+	Comments       []repositories.LibraryComment `json:"comments"`
+}
+
+const libraryFacetLimit = 50
+
+var (
+	ErrLibraryGameUnavailable    = errors.New("library game is not available")
+	ErrLibraryGameExists         = errors.New("game already exists in the library")
+	ErrInvalidLibraryModeration  = errors.New("action must be approve or reject")
+	ErrSteamAppAlreadyAttached   = errors.New("game already has a steam app id")
+	ErrSteamAppAlreadyInLibrary  = errors.New("steam app already has a library entry")
+	ErrInvalidLibraryReleaseYear = errors.New("invalid release year")
+	ErrInvalidLibraryCoverURL    = errors.New("cover must be an http or https url")
+	ErrInvalidLibraryMerge       = errors.New("sourceIds must list library games other than the target")
+)
+
+const (
+	LibraryModerationApprove = "approve"
+	LibraryModerationReject  = "reject"
+)
+
+type LibrarySubmission struct {
+	Name        string
+	Platform    string
+	ReleaseYear *int
+	CoverURL    string
+}
+
+type LibraryFacets struct {
+	Genres     []repositories.LibraryFacetCount `json:"genres"`
+	Categories []repositories.LibraryFacetCount `json:"categories"`
+	Tags       []repositories.LibraryFacetCount `json:"tags"`
+}
+
+type LibraryDuplicateGroup struct {
+	Key               string                                 `json:"key"`
+	SuggestedTargetID string                                 `json:"suggestedTargetId"`
+	Games             []repositories.LibraryDuplicateGameRow `json:"games"`
+}
+
+type RatingHistogramBucket struct {
+	Rating int   `json:"rating"`
+	Count  int64 `json:"count"`
+}
+
+type LibraryGameStatsResponse struct {
+	GameID                  string                            `json:"gameId"`
+	SteamAppID              *int                              `json:"steamAppId,omitempty"`
+	ProgressCount           int64                             `json:"progressCount"`
+	RatingsCount            int64                             `json:"ratingsCount"`
+	AverageRating           float64                           `json:"averageRating"`
+	RatingHistogram         []RatingHistogramBucket           `json:"ratingHistogram"`
+	ByStatus                map[string]int64                  `json:"byStatus"`
+	CompletionRate          float64                           `json:"completionRate"`
+	DropRate                float64                           `json:"dropRate"`
+	MedianCompletedPlaytime *float64                          `json:"medianCompletedPlaytime,omitempty"`
+	FollowingRatings        []repositories.FollowingRatingRow `json:"followingRatings"`
 }
 
 type GameSuggestion struct {
@@ -37,15 +99,21 @@ type GameSuggestion struct {
 
 type LibraryService struct {
 	libraryRepository *repositories.LibraryRepository
+	seriesRepository  *repositories.SeriesRepository
+	userRepository    *repositories.UserRepository
 	steamService      *SteamService
 }
 
 func NewLibraryService(
 	libraryRepo *repositories.LibraryRepository,
+	seriesRepo *repositories.SeriesRepository,
+	userRepo *repositories.UserRepository,
 	steamService *SteamService,
 ) *LibraryService {
 	return &LibraryService{
 		libraryRepository: libraryRepo,
+		seriesRepository:  seriesRepo,
+		userRepository:    userRepo,
 		steamService:      steamService,
 	}
 }
@@ -55,7 +123,7 @@ func (s *LibraryService) EnsureLibraryGameFromSteam(appID int) (*models.LibraryG
 		return nil, nil
 	}
 
-	existing, err := s.libraryRepository.GetBySteamAppID(appID)
+	existing, err := s.libraryRepository.GetBySteamAppIDWithRedirect(appID)
 	if err == nil && existing != nil {
 		return existing, nil
 	}
@@ -75,8 +143,9 @@ func (s *LibraryService) EnsureLibraryGameFromSteam(appID int) (*models.LibraryG
 
 	tags := mergeUnique(details.Genres, details.Categories)
 
+	steamAppID := details.AppID
 	game := &models.LibraryGame{
-		SteamAppID:       details.AppID,
+		SteamAppID:       &steamAppID,
 		Name:             details.Name,
 		ShortDescription: stripHTML(details.ShortDescription),
 		Description:      stripHTML(details.Description),
@@ -93,16 +162,154 @@ func (s *LibraryService) EnsureLibraryGameFromSteam(appID int) (*models.LibraryG
 	if err := s.libraryRepository.Upsert(game); err != nil {
 		return nil, err
 	}
+	return s.libraryRepository.GetBySteamAppID(steamAppID)
+}
+
+func (s *LibraryService) SubmitGame(userID string, submission LibrarySubmission) (*models.LibraryGame, error) {
+	name := strings.TrimSpace(submission.Name)
+	platform := strings.TrimSpace(submission.Platform)
+	if submission.ReleaseYear != nil && (*submission.ReleaseYear < 1950 || *submission.ReleaseYear > time.Now().Year()+5) {
+		return nil, ErrInvalidLibraryReleaseYear
+	}
+
+	exists, err := s.libraryRepository.ExistsByNameAndPlatform(name, platform)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrLibraryGameExists
+	}
+
+	cover := strings.TrimSpace(submission.CoverURL)
+	if cover != "" {
+		parsed, err := url.Parse(cover)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, ErrInvalidLibraryCoverURL
+		}
+	}
+	game := &models.LibraryGame{
+		Name:             name,
+		Platform:         platform,
+		ReleaseYear:      submission.ReleaseYear,
+		HeaderImage:      cover,
+		CapsuleImage:     cover,
+		Source:           models.LibraryGameSourceUser,
+		ModerationStatus: models.LibraryGameModerationPending,
+		SubmittedByID:    &userID,
+	}
+	if err := s.libraryRepository.Create(game); err != nil {
+		return nil, err
+	}
 	return game, nil
 }
 
-func (s *LibraryService) ListGames(limit, offset int, search, genre, sort, order string) ([]LibraryGameResponse, int64, error) {
-	rows, err := s.libraryRepository.ListWithStats(limit, offset, search, genre, sort, order)
+func (s *LibraryService) GetGameForProgress(id, userID string) (*models.LibraryGame, error) {
+	game, err := s.libraryRepository.GetByIDWithRedirect(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLibraryGameUnavailable
+		}
+		return nil, err
+	}
+
+	switch game.ModerationStatus {
+	case models.LibraryGameModerationApproved:
+		return game, nil
+	case models.LibraryGameModerationPending:
+		if game.SubmittedByID != nil && *game.SubmittedByID == userID {
+			return game, nil
+		}
+	}
+	return nil, ErrLibraryGameUnavailable
+}
+
+func (s *LibraryService) ListSubmissions(status models.LibraryGameModerationStatus, limit, offset int) ([]*models.LibraryGame, int64, error) {
+	games, err := s.libraryRepository.ListByModerationStatus(status, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
+	total, err := s.libraryRepository.CountByModerationStatus(status)
+	if err != nil {
+		return nil, 0, err
+	}
+	return games, total, nil
+}
+
+func (s *LibraryService) ModerateSubmission(id, action, note string) error {
+	status := models.LibraryGameModerationApproved
+	switch action {
+	case LibraryModerationApprove:
+	case LibraryModerationReject:
+		status = models.LibraryGameModerationRejected
+	default:
+		return ErrInvalidLibraryModeration
+	}
+	return s.libraryRepository.UpdateModeration(id, status, strings.TrimSpace(note), time.Now())
+}
 
-	total, err := s.libraryRepository.Count(search, genre)
+func (s *LibraryService) AttachSteamApp(id string, appID int) (*models.LibraryGame, error) {
+	game, err := s.libraryRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if game.SteamAppID != nil {
+		return nil, ErrSteamAppAlreadyAttached
+	}
+
+	if _, err := s.libraryRepository.GetBySteamAppIDWithRedirect(appID); err == nil {
+		return nil, ErrSteamAppAlreadyInLibrary
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	details, err := s.steamService.GetStoreDetails(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	game.SteamAppID = &appID
+	game.StoreURL = details.StoreURL
+	if game.ShortDescription == "" {
+		game.ShortDescription = stripHTML(details.ShortDescription)
+	}
+	if game.Description == "" {
+		game.Description = stripHTML(details.Description)
+	}
+	if game.HeaderImage == "" {
+		game.HeaderImage = details.HeaderImage
+	}
+	if game.CapsuleImage == "" {
+		game.CapsuleImage = details.CapsuleImage
+	}
+	if game.BackgroundImage == "" {
+		game.BackgroundImage = details.BackgroundImage
+	}
+	if len(game.Genres) == 0 {
+		game.Genres = details.Genres
+		if len(details.Genres) > 0 {
+			game.PrimaryGenre = details.Genres[0]
+		}
+	}
+	if len(game.Categories) == 0 {
+		game.Categories = details.Categories
+	}
+	if len(game.Tags) == 0 {
+		game.Tags = mergeUnique(details.Genres, details.Categories)
+	}
+
+	if err := s.libraryRepository.AttachSteamApp(game); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (s *LibraryService) ListGames(filter repositories.LibraryFilter, limit, offset int, sort, order string) ([]LibraryGameResponse, int64, error) {
+	rows, err := s.libraryRepository.ListWithStats(filter, limit, offset, sort, order)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.libraryRepository.Count(filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -121,6 +328,34 @@ func (s *LibraryService) ListGames(limit, offset int, search, genre, sort, order
 	return results, total, nil
 }
 
+func (s *LibraryService) GetFacets(filter repositories.LibraryFilter) (*LibraryFacets, error) {
+	genres, err := s.libraryRepository.ListFacets(filter, repositories.LibraryFacetGenres, libraryFacetLimit)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.libraryRepository.ListFacets(filter, repositories.LibraryFacetCategories, libraryFacetLimit)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.libraryRepository.ListFacets(filter, repositories.LibraryFacetTags, libraryFacetLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LibraryFacets{
+		Genres:     nonNilFacets(genres),
+		Categories: nonNilFacets(categories),
+		Tags:       nonNilFacets(tags),
+	}, nil
+}
+
+func nonNilFacets(facets []repositories.LibraryFacetCount) []repositories.LibraryFacetCount {
+	if facets == nil {
+		return []repositories.LibraryFacetCount{}
+	}
+	return facets
+}
+
 func (s *LibraryService) SuggestGames(query string, limit int) ([]GameSuggestion, string, error) {
 	trimmed := strings.TrimSpace(query)
 	if trimmed == "" {
@@ -134,7 +369,7 @@ func (s *LibraryService) SuggestGames(query string, limit int) ([]GameSuggestion
 		limit = 10
 	}
 
-	games, _, err := s.ListGames(limit, 0, trimmed, "", "progress", "desc")
+	games, _, err := s.ListGames(repositories.LibraryFilter{Search: trimmed}, limit, 0, "progress", "desc")
 	if err != nil {
 		return nil, "none", err
 	}
@@ -152,7 +387,7 @@ func (s *LibraryService) SuggestGames(query string, limit int) ([]GameSuggestion
 			suggestions = append(suggestions, GameSuggestion{
 				Source:     "library",
 				ID:         game.ID,
-				SteamAppID: game.SteamAppID,
+				SteamAppID: intValue(game.SteamAppID),
 				Name:       game.Name,
 				Icon:       icon,
 				StoreURL:   game.StoreURL,
@@ -184,44 +419,89 @@ func (s *LibraryService) SuggestGames(query string, limit int) ([]GameSuggestion
 	return suggestions, "steam", nil
 }
 
-func (s *LibraryService) GetGameByID(id string, commentsLimit, commentsOffset int) (*LibraryGameDetailResponse, error) {
-	row, err := s.libraryRepository.GetWithStatsByID(id)
+func (s *LibraryService) GetGameByID(id string, viewerID, commentsSort string, commentsLimit, commentsOffset int) (*LibraryGameDetailResponse, error) {
+	game, err := s.GetVisibleGame(id, viewerID)
 	if err != nil {
 		return nil, err
 	}
 
-	comments, err := s.libraryRepository.GetCommentsBySteamAppID(row.SteamAppID, commentsLimit, commentsOffset)
+	detail, err := s.getGameDetail(game.ID, viewerID, commentsSort, commentsLimit, commentsOffset)
 	if err != nil {
 		return nil, err
 	}
-
-	return &LibraryGameDetailResponse{
-		LibraryGameResponse: LibraryGameResponse{
-			LibraryGame:   row.LibraryGame,
-			AverageRating: row.AverageRating,
-			RatingsCount:  row.RatingsCount,
-			ReviewsCount:  row.ReviewsCount,
-			ProgressCount: row.ProgressCount,
-		},
-		Comments: comments,
-	}, nil
+	if game.ID != id {
+		detail.RedirectedFrom = id
+	}
+	return detail, nil
 }
 
-func (s *LibraryService) GetGameByAppID(appID int, commentsLimit, commentsOffset int) (*LibraryGameDetailResponse, error) {
+func (s *LibraryService) GetGameByAppID(appID int, viewerID, commentsSort string, commentsLimit, commentsOffset int) (*LibraryGameDetailResponse, error) {
 	if appID <= 0 {
 		return nil, gorm.ErrRecordNotFound
 	}
 
-	if _, err := s.EnsureLibraryGameFromSteam(appID); err != nil {
+	game, err := s.EnsureLibraryGameFromSteam(appID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canViewGame(game, viewerID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return s.getGameDetail(game.ID, viewerID, commentsSort, commentsLimit, commentsOffset)
+}
+
+func (s *LibraryService) GetVisibleGame(id, viewerID string) (*models.LibraryGame, error) {
+	game, err := s.libraryRepository.GetByIDWithRedirect(id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canViewGame(game, viewerID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return game, nil
+}
+
+func (s *LibraryService) ResolveGame(libraryGameID *string, steamAppID *int) *models.LibraryGame {
+	if libraryGameID != nil {
+		if game, err := s.libraryRepository.GetByIDWithRedirect(*libraryGameID); err == nil {
+			return game
+		}
+	}
+	if steamAppID != nil {
+		if game, err := s.libraryRepository.GetBySteamAppIDWithRedirect(*steamAppID); err == nil {
+			return game
+		}
+	}
+	return nil
+}
+
+func (s *LibraryService) canViewGame(game *models.LibraryGame, viewerID string) bool {
+	if game.ModerationStatus == models.LibraryGameModerationApproved {
+		return true
+	}
+	if viewerID == "" {
+		return false
+	}
+	if game.SubmittedByID != nil && *game.SubmittedByID == viewerID {
+		return true
+	}
+	user, err := s.userRepository.GetByID(viewerID)
+	return err == nil && user.IsAdmin
+}
+
+func (s *LibraryService) getGameDetail(id string, viewerID, commentsSort string, commentsLimit, commentsOffset int) (*LibraryGameDetailResponse, error) {
+	row, err := s.libraryRepository.GetWithStatsByID(id)
+	if err != nil {
 		return nil, err
 	}
 
-	row, err := s.libraryRepository.GetWithStatsBySteamAppID(appID)
+	comments, err := s.libraryRepository.GetCommentsByGameID(row.ID, viewerID, commentsSort, commentsLimit, commentsOffset)
 	if err != nil {
 		return nil, err
 	}
 
-	comments, err := s.libraryRepository.GetCommentsBySteamAppID(row.SteamAppID, commentsLimit, commentsOffset)
+	placements, err := s.seriesRepository.ListPlacementsByGameID(row.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -234,10 +514,158 @@ func (s *LibraryService) GetGameByAppID(appID int, commentsLimit, commentsOffset
 			ReviewsCount:  row.ReviewsCount,
 			ProgressCount: row.ProgressCount,
 		},
+		Series:   mapSeriesPlacements(placements),
 		Comments: comments,
 	}, nil
 }
 
+func (s *LibraryService) GetGameStats(id, viewerID string) (*LibraryGameStatsResponse, error) {
+	game, err := s.GetVisibleGame(id, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.libraryRepository.GetGameStats(game.ID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts []int64
+	if err := json.Unmarshal([]byte(row.RatingHistogram), &counts); err != nil {
+		return nil, err
+	}
+	histogram := make([]RatingHistogramBucket, 0, len(counts))
+	for i, count := range counts {
+		histogram = append(histogram, RatingHistogramBucket{Rating: i + 1, Count: count})
+	}
+
+	following := []repositories.FollowingRatingRow{}
+	if err := json.Unmarshal([]byte(row.FollowingRatings), &following); err != nil {
+		return nil, err
+	}
+
+	stats := &LibraryGameStatsResponse{
+		GameID:          game.ID,
+		SteamAppID:      game.SteamAppID,
+		ProgressCount:   row.ProgressCount,
+		RatingsCount:    row.RatingsCount,
+		AverageRating:   math.Round(row.AverageRating*100) / 100,
+		RatingHistogram: histogram,
+		ByStatus: map[string]int64{
+			string(models.GameStatusPlanToPlay): row.PlanToPlayCount,
+			string(models.GameStatusPlaying):    row.PlayingCount,
+			string(models.GameStatusCompleted):  row.CompletedCount,
+			string(models.GameStatusDropped):    row.DroppedCount,
+		},
+		MedianCompletedPlaytime: row.MedianCompletedPlaytime,
+		FollowingRatings:        following,
+	}
+
+	started := row.PlayingCount + row.CompletedCount + row.DroppedCount
+	if started > 0 {
+		stats.CompletionRate = math.Round(float64(row.CompletedCount)/float64(started)*1000) / 1000
+		stats.DropRate = math.Round(float64(row.DroppedCount)/float64(started)*1000) / 1000
+	}
+	return stats, nil
+}
+
+func (s *LibraryService) ListDuplicateGroups(limit, offset int) ([]LibraryDuplicateGroup, int64, error) {
+	rows, err := s.libraryRepository.ListDuplicateGroups(limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.libraryRepository.CountDuplicateGroups()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	groupIDs := make([][]string, 0, len(rows))
+	var allIDs []string
+	for _, row := range rows {
+		var ids []string
+		if err := json.Unmarshal([]byte(row.GameIDs), &ids); err != nil {
+			return nil, 0, err
+		}
+		groupIDs = append(groupIDs, ids)
+		allIDs = append(allIDs, ids...)
+	}
+
+	games, err := s.libraryRepository.ListWithEntryCountsByIDs(allIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	groupOf := make(map[string]int, len(allIDs))
+	for i, ids := range groupIDs {
+		for _, id := range ids {
+			groupOf[id] = i
+		}
+	}
+	members := make([][]repositories.LibraryDuplicateGameRow, len(rows))
+	for _, game := range games {
+		i := groupOf[game.ID]
+		members[i] = append(members[i], game)
+	}
+
+	groups := make([]LibraryDuplicateGroup, 0, len(rows))
+	for i, row := range rows {
+		if len(members[i]) == 0 {
+			continue
+		}
+		groups = append(groups, LibraryDuplicateGroup{
+			Key:               row.NameKey,
+			SuggestedTargetID: suggestMergeTarget(members[i]),
+			Games:             members[i],
+		})
+	}
+	return groups, total, nil
+}
+
+func suggestMergeTarget(games []repositories.LibraryDuplicateGameRow) string {
+	best := games[0]
+	for _, game := range games[1:] {
+		switch {
+		case game.ModerationStatus == models.LibraryGameModerationApproved && best.ModerationStatus != models.LibraryGameModerationApproved:
+			best = game
+		case game.ModerationStatus != best.ModerationStatus:
+		case game.EntryCount > best.EntryCount:
+			best = game
+		case game.EntryCount == best.EntryCount && len(game.Name) < len(best.Name):
+			best = game
+		}
+	}
+	return best.ID
+}
+
+func (s *LibraryService) MergeGames(targetID string, sourceIDs []string) (*models.LibraryGame, error) {
+	if len(sourceIDs) == 0 {
+		return nil, ErrInvalidLibraryMerge
+	}
+
+	target, err := s.libraryRepository.GetByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]*models.LibraryGame, 0, len(sourceIDs))
+	seen := make(map[string]bool, len(sourceIDs))
+	for _, id := range sourceIDs {
+		if id == target.ID || seen[id] {
+			return nil, ErrInvalidLibraryMerge
+		}
+		seen[id] = true
+		source, err := s.libraryRepository.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	if err := s.libraryRepository.MergeInto(target, sources); err != nil {
+		return nil, err
+	}
+	return s.libraryRepository.GetByID(target.ID)
+}
+
 func (s *LibraryService) WarmLibraryFromProgress(appID int) {
 	if appID <= 0 {
 		return
@@ -272,6 +700,13 @@ func mergeUnique(primary []string, secondary []string) []string {
 	return out
 }
 
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
 var htmlTagRegexp = regexp.MustCompile(`<[^>]+>`)
 
 func stripHTML(value string) string {
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+)
+
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrInvalidNotificationType = errors.New("invalid notification type")
+)
+
+type NotificationEvent struct {
+	Type        models.NotificationType
+	ActorID     string
+	RecipientID string
+	ActivityID  *string
+	CommentID   *string
+	GameName    *string
+}
+
+type NotificationResponse struct {
+	ID         string                  `json:"id"`
+	Type       models.NotificationType `json:"type"`
+	Actor      *ActivityUser           `json:"actor,omitempty"`
+	ActivityID *string                 `json:"activityId,omitempty"`
+	CommentID  *string                 `json:"commentId,omitempty"`
+	GameName   *string                 `json:"gameName,omitempty"`
+	Read       bool                    `json:"read"`
+	ReadAt     *time.Time              `json:"readAt,omitempty"`
+	CreatedAt  time.Time               `json:"createdAt"`
+}
+
+type NotificationPageResponse struct {
+	Data        []*NotificationResponse `json:"data"`
+	Total       int64                   `json:"total"`
+	UnreadCount int64                   `json:"unreadCount"`
+	Limit       int                     `json:"limit"`
+	Offset      int                     `json:"offset"`
+}
+
+type NotificationPreferenceResponse struct {
+	Type    models.NotificationType `json:"type"`
+	Enabled bool                    `json:"enabled"`
+}
+
+type NotificationService struct {
+	notificationRepository *repositories.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo *repositories.NotificationRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepository: notificationRepo,
+	}
+}
+
+func (s *NotificationService) Dispatch(event NotificationEvent) {
+	if err := s.dispatch(event); err != nil {
+		log.Printf("failed to dispatch %s notification: %v", event.Type, err)
+	}
+}
+
+func (s *NotificationService) dispatch(event NotificationEvent) error {
+	if event.ActorID == "" {
+		return nil
+	}
+
+	notification := &models.Notification{
+		UserID:     event.RecipientID,
+		ActorID:    &event.ActorID,
+		Type:       event.Type,
+		ActivityID: event.ActivityID,
+		CommentID:  event.CommentID,
+		GameName:   event.GameName,
+	}
+
+	if event.Type == models.NotificationTypeFollowedGame {
+		return s.notificationRepository.CreateForFollowers(notification)
+	}
+
+	if event.RecipientID == "" || event.RecipientID == event.ActorID {
+		return nil
+	}
+
+	enabled, err := s.notificationRepository.IsEnabled(event.RecipientID, event.Type)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	if event.Type == models.NotificationTypeActivityLike && event.ActivityID != nil {
+		exists, err := s.notificationRepository.ExistsUnread(event.RecipientID, event.ActorID, event.Type, *event.ActivityID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+	}
+
+	return s.notificationRepository.Create(notification)
+}
+
+func (s *NotificationService) List(userID string, unreadOnly bool, limit, offset int) (*NotificationPageResponse, error) {
+	rows, err := s.notificationRepository.ListByUserID(userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.notificationRepository.CountByUserID(userID, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepository.CountByUserID(userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*NotificationResponse, 0, len(rows))
+	for i := range rows {
+		results = append(results, mapNotificationRow(&rows[i]))
+	}
+
+	return &NotificationPageResponse{
+		Data:        results,
+		Total:       total,
+		UnreadCount: unread,
+		Limit:       limit,
+		Offset:      offset,
+	}, nil
+}
+
+func (s *NotificationService) UnreadCount(userID string) (int64, error) {
+	return s.notificationRepository.CountByUserID(userID, true)
+}
+
+func (s *NotificationService) MarkRead(id, userID string) error {
+	updated, err := s.notificationRepository.MarkRead(id, userID)
+	if err != nil {
+		return err
+	}
+	if updated > 0 {
+		return nil
+	}
+
+	exists, err := s.notificationRepository.Exists(id, userID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(userID string) error {
+	return s.notificationRepository.MarkAllRead(userID)
+}
+
+func (s *NotificationService) GetPreferences(userID string) ([]NotificationPreferenceResponse, error) {
+	stored, err := s.notificationRepository.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[models.NotificationType]bool, len(stored))
+	for _, pref := range stored {
+		enabled[pref.Type] = pref.Enabled
+	}
+
+	results := make([]NotificationPreferenceResponse, 0, len(models.NotificationTypes))
+	for _, notificationType := range models.NotificationTypes {
+		value, ok := enabled[notificationType]
+		if !ok {
+			value = true
+		}
+		results = append(results, NotificationPreferenceResponse{
+			Type:    notificationType,
+			Enabled: value,
+		})
+	}
+
+	return results, nil
+}
+
+func (s *NotificationService) UpdatePreferences(userID string, preferences map[string]bool) ([]NotificationPreferenceResponse, error) {
+	for key := range preferences {
+		if !models.NotificationType(key).IsValid() {
+			return nil, ErrInvalidNotificationType
+		}
+	}
+
+	for key, enabled := range preferences {
+		pref := &models.NotificationPreference{
+			UserID:  userID,
+			Type:    models.NotificationType(key),
+			Enabled: enabled,
+		}
+		if err := s.notificationRepository.UpsertPreference(pref); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetPreferences(userID)
+}
+
+func mapNotificationRow(row *repositories.NotificationRow) *NotificationResponse {
+	resp := &NotificationResponse{
+		ID:         row.ID,
+		Type:       row.Type,
+		ActivityID: row.ActivityID,
+		CommentID:  row.CommentID,
+		GameName:   row.GameName,
+		Read:       row.ReadAt != nil,
+		ReadAt:     row.ReadAt,
+		CreatedAt:  row.CreatedAt,
+	}
+
+	if row.ActorID != nil {
+		resp.Actor = &ActivityUser{
+			ID:          *row.ActorID,
+			DisplayName: stringOrEmpty(row.ActorDisplayName),
+			AvatarURL:   stringOrEmpty(row.ActorAvatarURL),
+		}
+	}
+
+	return resp
+}
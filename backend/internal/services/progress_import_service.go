@@ -0,0 +1,890 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+	"gamecheck/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	progressImportSyncLimit    = 50
+	progressImportMaxRows      = 5000
+	progressImportPollInterval = 5 * time.Second
+	progressImportStaleAfter   = 10 * time.Minute
+	progressImportSaveEvery    = 25
+	progressImportSearchLimit  = 5
+)
+
+var (
+	ErrInvalidImportFormat     = errors.New("invalid import format")
+	ErrInvalidImportMapping    = errors.New("invalid column mapping")
+	ErrInvalidImportDuplicates = errors.New("invalid duplicates mode")
+	ErrInvalidImportFile       = errors.New("invalid import file")
+	ErrEmptyImport             = errors.New("import file has no rows")
+	ErrImportTooLarge          = errors.New("import file has too many rows")
+)
+
+var progressImportFields = []string{
+	"name",
+	"status",
+	"rating",
+	"review",
+	"steamAppId",
+	"libraryGameId",
+	"platform",
+	"ownership",
+	"store",
+	"visibility",
+}
+
+var progressExportHeader = []string{
+	"name",
+	"status",
+	"rating",
+	"review",
+	"steamAppId",
+	"libraryGameId",
+	"platform",
+	"ownership",
+	"store",
+	"visibility",
+	"steamPlaytimeForever",
+	"createdAt",
+	"updatedAt",
+}
+
+var progressImportStatusAliases = map[string]models.GameStatus{
+	"plantoplay":    models.GameStatusPlanToPlay,
+	"plan":          models.GameStatusPlanToPlay,
+	"planned":       models.GameStatusPlanToPlay,
+	"backlog":       models.GameStatusPlanToPlay,
+	"wishlist":      models.GameStatusPlanToPlay,
+	"wanttoplay":    models.GameStatusPlanToPlay,
+	"toplay":        models.GameStatusPlanToPlay,
+	"впланах":       models.GameStatusPlanToPlay,
+	"запланировано": models.GameStatusPlanToPlay,
+	"хочупоиграть":  models.GameStatusPlanToPlay,
+	"playing":       models.GameStatusPlaying,
+	"inprogress":    models.GameStatusPlaying,
+	"started":       models.GameStatusPlaying,
+	"current":       models.GameStatusPlaying,
+	"играю":         models.GameStatusPlaying,
+	"впроцессе":     models.GameStatusPlaying,
+	"completed":     models.GameStatusCompleted,
+	"complete":      models.GameStatusCompleted,
+	"finished":      models.GameStatusCompleted,
+	"done":          models.GameStatusCompleted,
+	"beaten":        models.GameStatusCompleted,
+	"пройдено":      models.GameStatusCompleted,
+	"пройдена":      models.GameStatusCompleted,
+	"пройден":       models.GameStatusCompleted,
+	"dropped":       models.GameStatusDropped,
+	"abandoned":     models.GameStatusDropped,
+	"quit":          models.GameStatusDropped,
+	"брошено":       models.GameStatusDropped,
+	"брошена":       models.GameStatusDropped,
+	"заброшено":     models.GameStatusDropped,
+}
+
+type ProgressExport struct {
+	Filename    string
+	ContentType string
+	Body        []byte
+}
+
+type ProgressExportRow struct {
+	Name                 string                   `json:"name"`
+	Status               models.GameStatus        `json:"status"`
+	Rating               *int                     `json:"rating,omitempty"`
+	Review               string                   `json:"review,omitempty"`
+	SteamAppID           *int                     `json:"steamAppId,omitempty"`
+	LibraryGameID        *string                  `json:"libraryGameId,omitempty"`
+	Platform             string                   `json:"platform,omitempty"`
+	Ownership            models.OwnershipType     `json:"ownership,omitempty"`
+	Store                string                   `json:"store,omitempty"`
+	Visibility           models.ProfileVisibility `json:"visibility"`
+	SteamPlaytimeForever *int                     `json:"steamPlaytimeForever,omitempty"`
+	CreatedAt            time.Time                `json:"createdAt"`
+	UpdatedAt            time.Time                `json:"updatedAt"`
+}
+
+type ProgressImportRequest struct {
+	Format     string
+	Duplicates string
+	Mapping    map[string]string
+	Data       []byte
+}
+
+type ProgressImportService struct {
+	importRepository   *repositories.ProgressImportRepository
+	progressRepository *repositories.ProgressRepository
+	libraryService     *LibraryService
+	steamService       *SteamService
+}
+
+func NewProgressImportService(
+	importRepository *repositories.ProgressImportRepository,
+	progressRepository *repositories.ProgressRepository,
+	libraryService *LibraryService,
+	steamService *SteamService,
+) *ProgressImportService {
+	return &ProgressImportService{
+		importRepository:   importRepository,
+		progressRepository: progressRepository,
+		libraryService:     libraryService,
+		steamService:       steamService,
+	}
+}
+
+func (s *ProgressImportService) ExportProgress(userID, format string) (*ProgressExport, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		return nil, ErrInvalidImportFormat
+	}
+
+	rows, err := s.progressRepository.ListWithLibraryByUserID(userID, userID, repositories.ProgressFilter{}, nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	exportRows := make([]ProgressExportRow, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		exportRows = append(exportRows, ProgressExportRow{
+			Name:                 row.Name,
+			Status:               row.Status,
+			Rating:               row.Rating,
+			Review:               row.Review,
+			SteamAppID:           row.SteamAppID,
+			LibraryGameID:        row.LibraryGameID,
+			Platform:             row.Platform,
+			Ownership:            row.Ownership,
+			Store:                row.Store,
+			Visibility:           row.Visibility,
+			SteamPlaytimeForever: row.SteamPlaytimeForever,
+			CreatedAt:            row.CreatedAt,
+			UpdatedAt:            row.UpdatedAt,
+		})
+	}
+
+	if format == "json" {
+		body, err := json.MarshalIndent(exportRows, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &ProgressExport{
+			Filename:    "gamecheck-progress.json",
+			ContentType: "application/json; charset=utf-8",
+			Body:        body,
+		}, nil
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(progressExportHeader); err != nil {
+		return nil, err
+	}
+	for _, row := range exportRows {
+		record := []string{
+			row.Name,
+			string(row.Status),
+			formatOptionalInt(row.Rating),
+			row.Review,
+			formatOptionalInt(row.SteamAppID),
+			"",
+			row.Platform,
+			string(row.Ownership),
+			row.Store,
+			string(row.Visibility),
+			formatOptionalInt(row.SteamPlaytimeForever),
+			row.CreatedAt.UTC().Format(time.RFC3339),
+			row.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if row.LibraryGameID != nil {
+			record[5] = *row.LibraryGameID
+		}
+		for i := range record {
+			record[i] = escapeCSVFormula(record[i])
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return &ProgressExport{
+		Filename:    "gamecheck-progress.csv",
+		ContentType: "text/csv; charset=utf-8",
+		Body:        buf.Bytes(),
+	}, nil
+}
+
+func (s *ProgressImportService) StartImport(userID string, req ProgressImportRequest) (*models.ProgressImportJob, error) {
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format != "csv" && format != "json" {
+		return nil, ErrInvalidImportFormat
+	}
+
+	duplicates := models.ProgressImportDuplicateMode(strings.ToLower(strings.TrimSpace(req.Duplicates)))
+	if duplicates == "" {
+		duplicates = models.ProgressImportSkip
+	}
+	if !duplicates.IsValid() {
+		return nil, ErrInvalidImportDuplicates
+	}
+
+	rows, err := parseProgressImport(format, req.Data, req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	sync := len(rows) <= progressImportSyncLimit && !needsSteamLookup(rows)
+	status := models.ProgressImportPending
+	if sync {
+		status = models.ProgressImportRunning
+	}
+
+	job := &models.ProgressImportJob{
+		UserID:     userID,
+		Status:     status,
+		Format:     format,
+		Duplicates: duplicates,
+		TotalRows:  len(rows),
+		Rows:       rows,
+		Results:    []models.ProgressImportResult{},
+	}
+	if err := s.importRepository.Create(job); err != nil {
+		return nil, err
+	}
+
+	if sync {
+		s.processJob(job)
+	}
+
+	return job, nil
+}
+
+func needsSteamLookup(rows []models.ProgressImportRow) bool {
+	for _, row := range rows {
+		if strings.TrimSpace(row.LibraryGameID) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ProgressImportService) GetJob(id, userID string) (*models.ProgressImportJob, error) {
+	return s.importRepository.GetByIDAndUserID(id, userID)
+}
+
+func (s *ProgressImportService) Run(ctx context.Context) {
+	ticker := time.NewTicker(progressImportPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.processPending(ctx)
+		}
+	}
+}
+
+func (s *ProgressImportService) processPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		id, err := s.importRepository.ClaimNext(time.Now().Add(-progressImportStaleAfter))
+		if err != nil {
+			log.Printf("failed to claim progress import: %v", err)
+			return
+		}
+		if id == "" {
+			return
+		}
+
+		job, err := s.importRepository.GetByID(id)
+		if err != nil {
+			log.Printf("failed to load progress import %s: %v", id, err)
+			continue
+		}
+		s.processJob(job)
+	}
+}
+
+func (s *ProgressImportService) processJob(job *models.ProgressImportJob) {
+	job.Status = models.ProgressImportRunning
+	if len(job.Results) > job.ProcessedRows {
+		job.Results = job.Results[:job.ProcessedRows]
+	}
+
+	for i := job.ProcessedRows; i < len(job.Rows); i++ {
+		result := s.importRow(job.UserID, job.Duplicates, job.Rows[i])
+		job.Results = append(job.Results, result)
+		switch result.Action {
+		case models.ProgressImportCreated:
+			job.CreatedCount++
+		case models.ProgressImportUpdated:
+			job.UpdatedCount++
+		case models.ProgressImportSkipped:
+			job.SkippedCount++
+		default:
+			job.FailedCount++
+		}
+		job.ProcessedRows = i + 1
+
+		if job.ProcessedRows%progressImportSaveEvery == 0 && job.ProcessedRows < len(job.Rows) {
+			if err := s.importRepository.Update(job); err != nil {
+				log.Printf("failed to save progress import %s: %v", job.ID, err)
+			}
+		}
+	}
+
+	finishedAt := time.Now()
+	job.Status = models.ProgressImportCompleted
+	job.FinishedAt = &finishedAt
+	job.Rows = nil
+	if err := s.importRepository.Update(job); err != nil {
+		log.Printf("failed to save progress import %s: %v", job.ID, err)
+	}
+}
+
+func (s *ProgressImportService) importRow(userID string, duplicates models.ProgressImportDuplicateMode, row models.ProgressImportRow) models.ProgressImportResult {
+	result := models.ProgressImportResult{
+		Line: row.Line,
+		Name: strings.TrimSpace(row.Name),
+	}
+	fail := func(err error) models.ProgressImportResult {
+		result.Action = models.ProgressImportError
+		result.Error = err.Error()
+		return result
+	}
+
+	values, err := parseProgressImportRow(row)
+	if err != nil {
+		return fail(err)
+	}
+
+	libraryGame, steamAppID, err := s.resolveImportGame(userID, values.libraryGameID, values.steamAppID, values.name)
+	if err != nil {
+		return fail(err)
+	}
+
+	var libraryGameID *string
+	name := values.name
+	if libraryGame != nil {
+		libraryGameID = &libraryGame.ID
+		if strings.TrimSpace(libraryGame.Name) != "" {
+			name = ""
+			result.Name = libraryGame.Name
+		}
+	}
+	if libraryGame == nil {
+		if err := utils.ValidateGameName(name); err != nil {
+			return fail(err)
+		}
+	}
+	result.SteamAppID = steamAppID
+	result.LibraryGameID = libraryGameID
+
+	existing, err := s.progressRepository.FindByUserIDAndGame(userID, libraryGameID, steamAppID, values.name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fail(err)
+	}
+
+	if existing == nil {
+		status := values.status
+		if status == "" {
+			status = models.GameStatusPlanToPlay
+		}
+		visibility := values.visibility
+		if visibility == "" {
+			visibility = models.ProfileVisibilityPublic
+		}
+		progress := &models.Progress{
+			UserID:        userID,
+			Name:          name,
+			Status:        status,
+			Rating:        values.rating,
+			Review:        values.review,
+			SteamAppID:    steamAppID,
+			LibraryGameID: libraryGameID,
+			Platform:      values.platform,
+			Ownership:     models.OwnershipType(values.ownership),
+			Store:         values.store,
+			Visibility:    visibility,
+		}
+		if err := s.progressRepository.Create(progress); err != nil {
+			return fail(err)
+		}
+		if steamAppID != nil && libraryGame == nil {
+			s.libraryService.WarmLibraryFromProgress(*steamAppID)
+		}
+		result.Action = models.ProgressImportCreated
+		result.ProgressID = &progress.ID
+		return result
+	}
+
+	result.ProgressID = &existing.ID
+	if duplicates == models.ProgressImportSkip {
+		result.Action = models.ProgressImportSkipped
+		return result
+	}
+
+	var changed bool
+	if duplicates == models.ProgressImportOverwrite {
+		changed = overwriteImportedProgress(existing, values)
+	} else {
+		changed = mergeImportedProgress(existing, values)
+	}
+	if existing.LibraryGameID == nil && libraryGameID != nil {
+		existing.LibraryGameID = libraryGameID
+		existing.Name = name
+		changed = true
+	}
+	if existing.SteamAppID == nil && steamAppID != nil {
+		existing.SteamAppID = steamAppID
+		changed = true
+	}
+
+	if !changed {
+		result.Action = models.ProgressImportSkipped
+		return result
+	}
+	if err := s.progressRepository.Update(existing); err != nil {
+		return fail(err)
+	}
+	result.Action = models.ProgressImportUpdated
+	return result
+}
+
+func (s *ProgressImportService) resolveImportGame(userID string, libraryGameID *string, steamAppID *int, name string) (*models.LibraryGame, *int, error) {
+	if libraryGameID != nil {
+		lg, err := s.libraryService.GetGameForProgress(*libraryGameID, userID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("library game not found")
+		}
+		return lg, lg.SteamAppID, nil
+	}
+
+	if steamAppID == nil && name != "" {
+		results, err := s.steamService.SearchApps(name, progressImportSearchLimit)
+		if err == nil {
+			key := normalizeImportKey(name)
+			for _, candidate := range results {
+				if normalizeImportKey(candidate.Name) == key {
+					appID := candidate.AppID
+					steamAppID = &appID
+					break
+				}
+			}
+		}
+	}
+
+	if steamAppID == nil {
+		return nil, nil, nil
+	}
+	lg, err := s.libraryService.EnsureLibraryGameFromSteam(*steamAppID)
+	if err != nil || lg == nil {
+		return nil, steamAppID, nil
+	}
+	if lg.SteamAppID != nil {
+		steamAppID = lg.SteamAppID
+	}
+	return lg, steamAppID, nil
+}
+
+type progressImportValues struct {
+	name          string
+	status        models.GameStatus
+	rating        *int
+	review        string
+	steamAppID    *int
+	libraryGameID *string
+	platform      string
+	ownership     string
+	store         string
+	visibility    models.ProfileVisibility
+	fields        map[string]bool
+}
+
+func parseProgressImportRow(row models.ProgressImportRow) (*progressImportValues, error) {
+	values := &progressImportValues{
+		name:   strings.ReplaceAll(strings.TrimSpace(row.Name), "’", "'"),
+		review: strings.TrimSpace(row.Review),
+		fields: make(map[string]bool, len(row.Fields)),
+	}
+	for _, field := range row.Fields {
+		values.fields[field] = true
+	}
+
+	if id := strings.TrimSpace(row.LibraryGameID); id != "" {
+		values.libraryGameID = &id
+	}
+	if raw := strings.TrimSpace(row.SteamAppID); raw != "" {
+		appID, err := strconv.Atoi(raw)
+		if err != nil || appID <= 0 {
+			return nil, fmt.Errorf("invalid steam app id")
+		}
+		values.steamAppID = &appID
+	}
+	if values.libraryGameID == nil && values.steamAppID == nil && values.name == "" {
+		return nil, fmt.Errorf("game name is required")
+	}
+
+	if raw := strings.TrimSpace(row.Status); raw != "" {
+		status, ok := normalizeImportStatus(raw)
+		if !ok {
+			return nil, fmt.Errorf("unknown status %q", raw)
+		}
+		values.status = status
+	}
+
+	if raw := strings.TrimSpace(row.Rating); raw != "" {
+		rating, err := parseImportRating(raw)
+		if err != nil {
+			return nil, err
+		}
+		values.rating = &rating
+	}
+
+	if err := utils.ValidateReview(values.review); err != nil {
+		return nil, err
+	}
+
+	values.platform = resolvePlatformID(row.Platform)
+	values.ownership = strings.ToLower(strings.TrimSpace(row.Ownership))
+	values.store = resolveStoreID(row.Store)
+	if err := validateProgressOwnership(values.platform, values.ownership, values.store); err != nil {
+		return nil, err
+	}
+
+	values.visibility = models.ProfileVisibility(strings.ToLower(strings.TrimSpace(row.Visibility)))
+	if values.visibility != "" && !values.visibility.IsValid() {
+		return nil, ErrInvalidProgressVisibility
+	}
+
+	return values, nil
+}
+
+func overwriteImportedProgress(progress *models.Progress, values *progressImportValues) bool {
+	changed := false
+	if values.fields["status"] {
+		status := values.status
+		if status == "" {
+			status = models.GameStatusPlanToPlay
+		}
+		if progress.Status != status {
+			progress.Status = status
+			changed = true
+		}
+	}
+	if values.fields["rating"] && !equalOptionalInt(progress.Rating, values.rating) {
+		progress.Rating = values.rating
+		changed = true
+	}
+	if values.fields["review"] && progress.Review != values.review {
+		progress.Review = values.review
+		changed = true
+	}
+	if values.fields["platform"] && progress.Platform != values.platform {
+		progress.Platform = values.platform
+		changed = true
+	}
+	if values.fields["ownership"] && string(progress.Ownership) != values.ownership {
+		progress.Ownership = models.OwnershipType(values.ownership)
+		changed = true
+	}
+	if values.fields["store"] && progress.Store != values.store {
+		progress.Store = values.store
+		changed = true
+	}
+	if values.fields["visibility"] {
+		visibility := values.visibility
+		if visibility == "" {
+			visibility = models.ProfileVisibilityPublic
+		}
+		if progress.Visibility != visibility {
+			progress.Visibility = visibility
+			changed = true
+		}
+	}
+	return changed
+}
+
+func equalOptionalInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func mergeImportedProgress(progress *models.Progress, values *progressImportValues) bool {
+	changed := false
+	if values.status != "" && progress.Status == models.GameStatusPlanToPlay && values.status != progress.Status {
+		progress.Status = values.status
+		changed = true
+	}
+	if values.rating != nil && progress.Rating == nil {
+		progress.Rating = values.rating
+		changed = true
+	}
+	if values.review != "" && progress.Review == "" {
+		progress.Review = values.review
+		changed = true
+	}
+	if values.platform != "" && progress.Platform == "" {
+		progress.Platform = values.platform
+		changed = true
+	}
+	if values.ownership != "" && progress.Ownership == "" {
+		progress.Ownership = models.OwnershipType(values.ownership)
+		changed = true
+	}
+	if values.store != "" && progress.Store == "" {
+		progress.Store = values.store
+		changed = true
+	}
+	return changed
+}
+
+func parseProgressImport(format string, data []byte, mapping map[string]string) ([]models.ProgressImportRow, error) {
+	columns, err := progressImportColumns(mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []map[string]string
+	var lines []int
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	if format == "json" {
+		var items []map[string]interface{}
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, ErrInvalidImportFile
+		}
+		for i, item := range items {
+			record := make(map[string]string, len(item))
+			for key, value := range item {
+				record[normalizeImportKey(key)] = stringifyImportValue(value)
+			}
+			records = append(records, record)
+			lines = append(lines, i+1)
+		}
+	} else {
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.Comma = detectCSVDelimiter(data)
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+
+		header, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, ErrEmptyImport
+			}
+			return nil, ErrInvalidImportFile
+		}
+		keys := make([]string, len(header))
+		for i, column := range header {
+			keys[i] = normalizeImportKey(column)
+		}
+
+		for {
+			fields, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, ErrInvalidImportFile
+			}
+			line, _ := reader.FieldPos(0)
+			record := make(map[string]string, len(fields))
+			for i, value := range fields {
+				if i < len(keys) {
+					record[keys[i]] = unescapeCSVFormula(value)
+				}
+			}
+			records = append(records, record)
+			lines = append(lines, line)
+		}
+	}
+
+	rows := make([]models.ProgressImportRow, 0, len(records))
+	for i, record := range records {
+		value := func(field string) string {
+			return strings.TrimSpace(record[columns[field]])
+		}
+		row := models.ProgressImportRow{
+			Line:          lines[i],
+			Name:          value("name"),
+			Status:        value("status"),
+			Rating:        value("rating"),
+			Review:        value("review"),
+			SteamAppID:    value("steamAppId"),
+			LibraryGameID: value("libraryGameId"),
+			Platform:      value("platform"),
+			Ownership:     value("ownership"),
+			Store:         value("store"),
+			Visibility:    value("visibility"),
+		}
+		for _, field := range progressImportFields {
+			if _, ok := record[columns[field]]; ok {
+				row.Fields = append(row.Fields, field)
+			}
+		}
+		if row.Name == "" && row.SteamAppID == "" && row.LibraryGameID == "" &&
+			row.Status == "" && row.Rating == "" && row.Review == "" {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+	if len(rows) > progressImportMaxRows {
+		return nil, ErrImportTooLarge
+	}
+	return rows, nil
+}
+
+func progressImportColumns(mapping map[string]string) (map[string]string, error) {
+	fields := make(map[string]string, len(progressImportFields))
+	columns := make(map[string]string, len(progressImportFields))
+	for _, field := range progressImportFields {
+		fields[normalizeImportKey(field)] = field
+		columns[field] = normalizeImportKey(field)
+	}
+
+	for field, column := range mapping {
+		name, ok := fields[normalizeImportKey(field)]
+		if !ok || strings.TrimSpace(column) == "" {
+			return nil, ErrInvalidImportMapping
+		}
+		columns[name] = normalizeImportKey(column)
+	}
+	return columns, nil
+}
+
+func normalizeImportKey(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func normalizeImportStatus(value string) (models.GameStatus, bool) {
+	status, ok := progressImportStatusAliases[normalizeImportKey(value)]
+	return status, ok
+}
+
+func parseImportRating(value string) (int, error) {
+	if idx := strings.Index(value, "/"); idx >= 0 {
+		value = value[:idx]
+	}
+	number, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(value), ",", "."), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rating")
+	}
+	rating := int(math.Round(number))
+	if err := utils.ValidateRating(rating); err != nil {
+		return 0, err
+	}
+	return rating, nil
+}
+
+func resolvePlatformID(value string) string {
+	value = strings.TrimSpace(value)
+	for _, platform := range models.GamePlatforms {
+		if strings.EqualFold(platform.ID, value) || strings.EqualFold(platform.Name, value) {
+			return platform.ID
+		}
+	}
+	return strings.ToLower(value)
+}
+
+func resolveStoreID(value string) string {
+	value = strings.TrimSpace(value)
+	for _, store := range models.GameStores {
+		if strings.EqualFold(store.ID, value) || strings.EqualFold(store.Name, value) {
+			return store.ID
+		}
+	}
+	return strings.ToLower(value)
+}
+
+func detectCSVDelimiter(data []byte) rune {
+	header := data
+	if idx := bytes.IndexByte(data, '\n'); idx >= 0 {
+		header = data[:idx]
+	}
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func escapeCSVFormula(value string) string {
+	if startsWithCSVFormula(value) {
+		return "'" + value
+	}
+	return value
+}
+
+func unescapeCSVFormula(value string) string {
+	if strings.HasPrefix(value, "'") && startsWithCSVFormula(value) {
+		return value[1:]
+	}
+	return value
+}
+
+func startsWithCSVFormula(value string) bool {
+	value = strings.TrimLeft(value, "'")
+	return value != "" && strings.IndexByte("=+-@\t\r", value[0]) >= 0
+}
+
+func stringifyImportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
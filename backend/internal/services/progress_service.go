@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -10,19 +11,49 @@ import (
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidProgressVisibility = errors.New("invalid visibility")
+	ErrInvalidPlatform           = errors.New("invalid platform")
+	ErrInvalidOwnership          = errors.New("invalid ownership type")
+	ErrInvalidStore              = errors.New("invalid store")
+)
+
+type ProgressOwnership struct {
+	Platform  string
+	Ownership string
+	Store     string
+}
+
+type ProgressOwnershipUpdate struct {
+	Platform  *string
+	Ownership *string
+	Store     *string
+}
+
+type PlatformCatalog struct {
+	Platforms      []models.GamePlatform  `json:"platforms"`
+	OwnershipTypes []models.OwnershipType `json:"ownershipTypes"`
+	Stores         []models.GameStore     `json:"stores"`
+}
+
 type ProgressGameResponse struct {
-	ID                   string            `json:"id"`
-	UserID               string            `json:"userId"`
-	Name                 string            `json:"name"`
-	Status               models.GameStatus `json:"status"`
-	Rating               *int              `json:"rating,omitempty"`
-	Review               string            `json:"review,omitempty"`
-	SteamAppID           *int              `json:"steamAppId,omitempty"`
-	SteamIconURL         string            `json:"steamIconUrl,omitempty"`
-	SteamStoreURL        string            `json:"steamStoreUrl,omitempty"`
-	SteamPlaytimeForever *int              `json:"steamPlaytimeForever,omitempty"`
-	CreatedAt            time.Time         `json:"createdAt"`
-	UpdatedAt            time.Time         `json:"updatedAt"`
+	ID                   string                   `json:"id"`
+	UserID               string                   `json:"userId"`
+	Name                 string                   `json:"name"`
+	Status               models.GameStatus        `json:"status"`
+	Rating               *int                     `json:"rating,omitempty"`
+	Review               string                   `json:"review,omitempty"`
+	SteamAppID           *int                     `json:"steamAppId,omitempty"`
+	LibraryGameID        *string                  `json:"libraryGameId,omitempty"`
+	SteamIconURL         string                   `json:"steamIconUrl,omitempty"`
+	SteamStoreURL        string                   `json:"steamStoreUrl,omitempty"`
+	SteamPlaytimeForever *int                     `json:"steamPlaytimeForever,omitempty"`
+	Platform             string                   `json:"platform,omitempty"`
+	Ownership            models.OwnershipType     `json:"ownership,omitempty"`
+	Store                string                   `json:"store,omitempty"`
+	Visibility           models.ProfileVisibility `json:"visibility"`
+	CreatedAt            time.Time                `json:"createdAt"`
+	UpdatedAt            time.Time                `json:"updatedAt"`
 }
 
 type ProgressSummary struct {
@@ -30,21 +61,25 @@ type ProgressSummary struct {
 	AvgRating   float64        `json:"avgRating"`
 	RatingCount int            `json:"ratingCount"`
 	ByStatus    map[string]int `json:"byStatus"`
+	ByPlatform  map[string]int `json:"byPlatform"`
+	ByOwnership map[string]int `json:"byOwnership"`
 }
 
 type ProgressPageResponse struct {
-	Data    []*ProgressGameResponse `json:"data"`
-	Total   int64                   `json:"total"`
-	Limit   int                     `json:"limit"`
-	Offset  int                     `json:"offset"`
-	Summary *ProgressSummary        `json:"summary,omitempty"`
+	Data       []*ProgressGameResponse `json:"data"`
+	Total      int64                   `json:"total"`
+	Limit      int                     `json:"limit"`
+	Offset     int                     `json:"offset"`
+	NextCursor *string                 `json:"nextCursor"`
+	Summary    *ProgressSummary        `json:"summary,omitempty"`
 }
 
 type ProgressService struct {
-	progressRepository *repositories.ProgressRepository
-	activityRepository *repositories.ActivityRepository
-	steamService       *SteamService
-	libraryService     *LibraryService
+	progressRepository  *repositories.ProgressRepository
+	activityRepository  *repositories.ActivityRepository
+	steamService        *SteamService
+	libraryService      *LibraryService
+	notificationService *NotificationService
 }
 
 func NewProgressService(
@@ -52,17 +87,19 @@ func NewProgressService(
 	activityRepo *repositories.ActivityRepository,
 	steamService *SteamService,
 	libraryService *LibraryService,
+	notificationService *NotificationService,
 ) *ProgressService {
 	return &ProgressService{
-		progressRepository: progressRepo,
-		activityRepository: activityRepo,
-		steamService:       steamService,
-		libraryService:     libraryService,
+		progressRepository:  progressRepo,
+		activityRepository:  activityRepo,
+		steamService:        steamService,
+		libraryService:      libraryService,
+		notificationService: notificationService,
 	}
 }
 
 func (s *ProgressService) AddGame(userID, name, status string, rating *int, review string) (*ProgressGameResponse, error) {
-	return s.AddGameWithSteamData(userID, name, status, rating, review, nil, nil)
+	return s.AddGameWithSteamData(userID, name, status, rating, review, nil, nil, nil, models.ProfileVisibilityPublic, ProgressOwnership{})
 }
 
 func (s *ProgressService) AddGameWithSteamData(
@@ -70,17 +107,41 @@ func (s *ProgressService) AddGameWithSteamData(
 	rating *int,
 	review string,
 	steamAppID *int,
+	libraryGameID *string,
 	steamPlaytimeForever *int,
+	visibility models.ProfileVisibility,
+	ownership ProgressOwnership,
 ) (*ProgressGameResponse, error) {
 	gameStatus := models.GameStatus(status)
+	if visibility == "" {
+		visibility = models.ProfileVisibilityPublic
+	}
+	if !visibility.IsValid() {
+		return nil, ErrInvalidProgressVisibility
+	}
+	if err := validateProgressOwnership(ownership.Platform, ownership.Ownership, ownership.Store); err != nil {
+		return nil, err
+	}
 
 	nameToStore := name
 	activityName := name
 	var libraryGame *models.LibraryGame
 
-	if steamAppID != nil && s.libraryService != nil {
+	if libraryGameID != nil && s.libraryService != nil {
+		lg, err := s.libraryService.GetGameForProgress(*libraryGameID, userID)
+		if err != nil {
+			return nil, err
+		}
+		libraryGame = lg
+		steamAppID = lg.SteamAppID
+		activityName = lg.Name
+		nameToStore = ""
+	} else if steamAppID != nil && s.libraryService != nil {
 		if lg, err := s.libraryService.EnsureLibraryGameFromSteam(*steamAppID); err == nil && lg != nil {
 			libraryGame = lg
+			if lg.SteamAppID != nil {
+				steamAppID = lg.SteamAppID
+			}
 			if strings.TrimSpace(lg.Name) != "" {
 				activityName = lg.Name
 				nameToStore = ""
@@ -88,6 +149,11 @@ func (s *ProgressService) AddGameWithSteamData(
 		}
 	}
 
+	var linkedGameID *string
+	if libraryGame != nil {
+		linkedGameID = &libraryGame.ID
+	}
+
 	progress := &models.Progress{
 		ID:                   uuid.New().String(),
 		UserID:               userID,
@@ -96,23 +162,37 @@ func (s *ProgressService) AddGameWithSteamData(
 		Rating:               rating,
 		Review:               review,
 		SteamAppID:           steamAppID,
+		LibraryGameID:        linkedGameID,
 		SteamPlaytimeForever: steamPlaytimeForever,
+		Platform:             ownership.Platform,
+		Ownership:            models.OwnershipType(ownership.Ownership),
+		Store:                ownership.Store,
+		Visibility:           visibility,
 	}
 
 	if err := s.progressRepository.Create(progress); err != nil {
 		return nil, err
 	}
 
-	activity := &models.Activity{
-		ID:         uuid.New().String(),
-		UserID:     userID,
-		Type:       models.ActivityTypeAddGame,
-		ProgressID: &progress.ID,
-		GameName:   &activityName,
-		Status:     &progress.Status,
-		Rating:     rating,
+	if progress.Visibility != models.ProfileVisibilityPrivate {
+		activity := &models.Activity{
+			ID:         uuid.New().String(),
+			UserID:     userID,
+			Type:       models.ActivityTypeAddGame,
+			ProgressID: &progress.ID,
+			GameName:   &activityName,
+			Status:     &progress.Status,
+			Rating:     rating,
+		}
+		if err := s.activityRepository.Create(activity); err == nil {
+			s.notificationService.Dispatch(NotificationEvent{
+				Type:       models.NotificationTypeFollowedGame,
+				ActorID:    userID,
+				ActivityID: &activity.ID,
+				GameName:   &activityName,
+			})
+		}
 	}
-	s.activityRepository.Create(activity)
 
 	if steamAppID != nil && s.libraryService != nil && libraryGame == nil {
 		s.libraryService.WarmLibraryFromProgress(*steamAppID)
@@ -127,15 +207,38 @@ func (s *ProgressService) UpdateGame(
 	rating *int,
 	review *string,
 	steamAppID *int,
+	libraryGameID *string,
 	steamPlaytimeForever *int,
+	visibility *string,
+	ownership ProgressOwnershipUpdate,
 ) (*ProgressGameResponse, error) {
 	progress, err := s.progressRepository.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	if visibility != nil {
+		value := models.ProfileVisibility(*visibility)
+		if !value.IsValid() {
+			return nil, ErrInvalidProgressVisibility
+		}
+		progress.Visibility = value
+	}
+	if ownership.Platform != nil {
+		progress.Platform = *ownership.Platform
+	}
+	if ownership.Ownership != nil {
+		progress.Ownership = models.OwnershipType(*ownership.Ownership)
+	}
+	if ownership.Store != nil {
+		progress.Store = *ownership.Store
+	}
+	if err := validateProgressOwnership(progress.Platform, string(progress.Ownership), progress.Store); err != nil {
+		return nil, err
+	}
+
 	oldStatus := progress.Status
-	if name != nil && progress.SteamAppID == nil && steamAppID == nil {
+	if name != nil && progress.SteamAppID == nil && steamAppID == nil && progress.LibraryGameID == nil && libraryGameID == nil {
 		progress.Name = *name
 	}
 	if status != nil {
@@ -155,9 +258,19 @@ func (s *ProgressService) UpdateGame(
 	}
 
 	var libraryGame *models.LibraryGame
-	if progress.SteamAppID != nil && s.libraryService != nil {
+	if libraryGameID != nil && s.libraryService != nil {
+		lg, err := s.libraryService.GetGameForProgress(*libraryGameID, progress.UserID)
+		if err != nil {
+			return nil, err
+		}
+		libraryGame = lg
+		progress.LibraryGameID = &lg.ID
+		progress.SteamAppID = lg.SteamAppID
+		progress.Name = ""
+	} else if progress.SteamAppID != nil && s.libraryService != nil {
 		if lg, err := s.libraryService.EnsureLibraryGameFromSteam(*progress.SteamAppID); err == nil && lg != nil {
 			libraryGame = lg
+			progress.LibraryGameID = &lg.ID
 			if strings.TrimSpace(lg.Name) != "" {
 				progress.Name = ""
 			}
@@ -175,6 +288,10 @@ func (s *ProgressService) UpdateGame(
 		activityName = *name
 	}
 
+	if progress.Visibility == models.ProfileVisibilityPrivate {
+		return s.getProgressView(progress.ID)
+	}
+
 	if status != nil && oldStatus != progress.Status {
 		activity := &models.Activity{
 			ID:         uuid.New().String(),
@@ -205,17 +322,24 @@ func (s *ProgressService) DeleteGame(id string) error {
 	return s.progressRepository.Delete(id)
 }
 
-func (s *ProgressService) GetUserGamesPage(userID, status string, limit, offset int, includeSummary bool) (*ProgressPageResponse, error) {
+func (s *ProgressService) GetUserGamesPage(userID, viewerID string, filter repositories.ProgressFilter, cursor *repositories.Cursor, limit, offset int, includeSummary bool) (*ProgressPageResponse, error) {
 	var rows []repositories.ProgressRow
+	var nextCursor *string
 	if limit > 0 {
 		var err error
-		rows, err = s.progressRepository.ListWithLibraryByUserID(userID, status, limit, offset)
+		rows, err = s.progressRepository.ListWithLibraryByUserID(userID, viewerID, filter, cursor, limit+1, offset)
 		if err != nil {
 			return nil, err
 		}
+		if len(rows) > limit {
+			rows = rows[:limit]
+			last := rows[len(rows)-1]
+			next := repositories.EncodeCursor(repositories.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+			nextCursor = &next
+		}
 	}
 
-	total, err := s.progressRepository.CountByUserID(userID, status)
+	total, err := s.progressRepository.CountByUserID(userID, viewerID, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -226,14 +350,15 @@ func (s *ProgressService) GetUserGamesPage(userID, status string, limit, offset
 	}
 
 	response := &ProgressPageResponse{
-		Data:   results,
-		Total:  total,
-		Limit:  limit,
-		Offset: offset,
+		Data:       results,
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+		NextCursor: nextCursor,
 	}
 
 	if includeSummary {
-		stats, err := s.progressRepository.GetStatsByUserID(userID)
+		stats, err := s.progressRepository.GetStatsByUserID(userID, viewerID)
 		if err != nil {
 			return nil, err
 		}
@@ -242,16 +367,36 @@ func (s *ProgressService) GetUserGamesPage(userID, status string, limit, offset
 			AvgRating:   stats.AvgRating,
 			RatingCount: int(stats.RatingCount),
 			ByStatus:    make(map[string]int),
+			ByPlatform:  make(map[string]int),
+			ByOwnership: make(map[string]int),
 		}
 		for key, value := range stats.ByStatus {
 			summary.ByStatus[key] = int(value)
 		}
+		for key, value := range stats.ByPlatform {
+			summary.ByPlatform[key] = int(value)
+		}
+		for key, value := range stats.ByOwnership {
+			summary.ByOwnership[key] = int(value)
+		}
 		response.Summary = summary
 	}
 
 	return response, nil
 }
 
+func (s *ProgressService) GetPlatformCatalog() *PlatformCatalog {
+	return &PlatformCatalog{
+		Platforms:      models.GamePlatforms,
+		OwnershipTypes: models.OwnershipTypes,
+		Stores:         models.GameStores,
+	}
+}
+
+func (s *ProgressService) ValidateFilter(filter repositories.ProgressFilter) error {
+	return validateProgressOwnership(filter.Platform, filter.Ownership, filter.Store)
+}
+
 func (s *ProgressService) GetGameByID(id string) (*models.Progress, error) {
 	return s.progressRepository.GetByID(id)
 }
@@ -271,6 +416,7 @@ func (s *ProgressService) UpdateSteamData(id string, steamAppID *int, steamPlayt
 
 	if progress.SteamAppID != nil && s.libraryService != nil {
 		if lg, err := s.libraryService.EnsureLibraryGameFromSteam(*progress.SteamAppID); err == nil && lg != nil {
+			progress.LibraryGameID = &lg.ID
 			if strings.TrimSpace(lg.Name) != "" {
 				progress.Name = ""
 			}
@@ -284,9 +430,36 @@ func (s *ProgressService) UpdateSteamData(id string, steamAppID *int, steamPlayt
 	return s.getProgressView(progress.ID)
 }
 
-func (s *ProgressService) ExistsForUser(userID string, steamAppID *int, name string) (bool, error) {
+func (s *ProgressService) ExistsForUser(userID string, steamAppID *int, libraryGameID *string, name string) (bool, error) {
+	libraryGameIDs := make([]string, 0, 2)
+	steamAppIDs := make([]int, 0, 2)
+	if libraryGameID != nil {
+		libraryGameIDs = append(libraryGameIDs, *libraryGameID)
+	}
 	if steamAppID != nil {
-		exists, err := s.progressRepository.ExistsByUserIDAndSteamAppID(userID, *steamAppID)
+		steamAppIDs = append(steamAppIDs, *steamAppID)
+	}
+	if s.libraryService != nil {
+		if game := s.libraryService.ResolveGame(libraryGameID, steamAppID); game != nil {
+			libraryGameIDs = append(libraryGameIDs, game.ID)
+			if game.SteamAppID != nil {
+				steamAppIDs = append(steamAppIDs, *game.SteamAppID)
+			}
+		}
+	}
+
+	for _, id := range libraryGameIDs {
+		exists, err := s.progressRepository.ExistsByUserIDAndLibraryGameID(userID, id)
+		if err != nil {
+			return false, err
+		}
+		if exists {
+			return true, nil
+		}
+	}
+
+	for _, appID := range steamAppIDs {
+		exists, err := s.progressRepository.ExistsByUserIDAndSteamAppID(userID, appID)
 		if err != nil {
 			return false, err
 		}
@@ -322,10 +495,28 @@ func mapProgressRow(row *repositories.ProgressRow) *ProgressGameResponse {
 		Rating:               row.Rating,
 		Review:               row.Review,
 		SteamAppID:           row.SteamAppID,
+		LibraryGameID:        row.LibraryGameID,
 		SteamIconURL:         row.SteamIconURL,
 		SteamStoreURL:        row.SteamStoreURL,
 		SteamPlaytimeForever: row.SteamPlaytimeForever,
+		Platform:             row.Platform,
+		Ownership:            row.Ownership,
+		Store:                row.Store,
+		Visibility:           row.Visibility,
 		CreatedAt:            row.CreatedAt,
 		UpdatedAt:            row.UpdatedAt,
 	}
 }
+
+func validateProgressOwnership(platform, ownership, store string) error {
+	if platform != "" && !models.IsValidPlatform(platform) {
+		return ErrInvalidPlatform
+	}
+	if ownership != "" && !models.OwnershipType(ownership).IsValid() {
+		return ErrInvalidOwnership
+	}
+	if store != "" && !models.IsValidStore(store) {
+		return ErrInvalidStore
+	}
+	return nil
+}
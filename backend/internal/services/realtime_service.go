@@ -0,0 +1,221 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gamecheck/internal/infra/db/repositories"
+	"gamecheck/internal/infra/realtime"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RealtimeEventActivity     = "activity"
+	RealtimeEventNotification = "notification"
+
+	realtimeReplayLimit = 50
+)
+
+type realtimeMessage struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	UserID string `json:"userId"`
+}
+
+type RealtimeService struct {
+	hub                    *realtime.Hub
+	activityRepository     *repositories.ActivityRepository
+	subscriptionRepository *repositories.SubscriptionRepository
+	notificationRepository *repositories.NotificationRepository
+}
+
+func NewRealtimeService(
+	hub *realtime.Hub,
+	activityRepo *repositories.ActivityRepository,
+	subscriptionRepo *repositories.SubscriptionRepository,
+	notificationRepo *repositories.NotificationRepository,
+) *RealtimeService {
+	return &RealtimeService{
+		hub:                    hub,
+		activityRepository:     activityRepo,
+		subscriptionRepository: subscriptionRepo,
+		notificationRepository: notificationRepo,
+	}
+}
+
+func (s *RealtimeService) Subscribe(userID, lastEventID string) (*realtime.Client, []realtime.Event, error) {
+	client := s.hub.Subscribe(userID)
+
+	since, ok := parseRealtimeEventID(lastEventID)
+	if !ok {
+		return client, nil, nil
+	}
+
+	replay, err := s.replaySince(userID, since)
+	if err != nil {
+		s.hub.Unsubscribe(client)
+		return nil, nil, err
+	}
+
+	return client, replay, nil
+}
+
+func (s *RealtimeService) Unsubscribe(client *realtime.Client) {
+	s.hub.Unsubscribe(client)
+}
+
+func (s *RealtimeService) Close() {
+	s.hub.Close()
+}
+
+func (s *RealtimeService) HandlePayload(payload string) {
+	var msg realtimeMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		log.Printf("invalid realtime payload %q: %v", payload, err)
+		return
+	}
+
+	var err error
+	switch msg.Kind {
+	case RealtimeEventActivity:
+		err = s.deliverActivity(msg)
+	case RealtimeEventNotification:
+		err = s.deliverNotification(msg)
+	}
+	if err != nil {
+		log.Printf("failed to deliver realtime %s %s: %v", msg.Kind, msg.ID, err)
+	}
+}
+
+func (s *RealtimeService) deliverActivity(msg realtimeMessage) error {
+	connected := s.hub.ConnectedUserIDs()
+	if len(connected) == 0 {
+		return nil
+	}
+
+	recipients, err := s.subscriptionRepository.FilterFollowers(msg.UserID, connected)
+	if err != nil {
+		return err
+	}
+	if s.hub.IsConnected(msg.UserID) {
+		recipients = append(recipients, msg.UserID)
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	visible, err := s.activityRepository.FilterViewers(msg.ID, recipients)
+	if err != nil {
+		return err
+	}
+	if len(visible) == 0 {
+		return nil
+	}
+
+	row, err := s.activityRepository.GetRowByID(msg.ID, msg.UserID)
+	if err != nil {
+		return err
+	}
+
+	event := activityEvent(row)
+	for _, userID := range visible {
+		s.hub.Publish(userID, event)
+	}
+	return nil
+}
+
+func (s *RealtimeService) deliverNotification(msg realtimeMessage) error {
+	if !s.hub.IsConnected(msg.UserID) {
+		return nil
+	}
+
+	row, err := s.notificationRepository.GetRowByID(msg.ID)
+	if err != nil {
+		return err
+	}
+
+	s.hub.Publish(msg.UserID, notificationEvent(row))
+	return nil
+}
+
+func (s *RealtimeService) replaySince(userID string, since repositories.Cursor) ([]realtime.Event, error) {
+	activities, err := s.activityRepository.GetFeedRowsSince(userID, since, realtimeReplayLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.notificationRepository.ListSince(userID, since, realtimeReplayLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	type timedEvent struct {
+		at    time.Time
+		id    string
+		event realtime.Event
+	}
+
+	timed := make([]timedEvent, 0, len(activities)+len(notifications))
+	for i := range activities {
+		timed = append(timed, timedEvent{at: activities[i].CreatedAt, id: activities[i].ID, event: activityEvent(&activities[i])})
+	}
+	for i := range notifications {
+		timed = append(timed, timedEvent{at: notifications[i].CreatedAt, id: notifications[i].ID, event: notificationEvent(&notifications[i])})
+	}
+
+	sort.SliceStable(timed, func(i, j int) bool {
+		if !timed[i].at.Equal(timed[j].at) {
+			return timed[i].at.Before(timed[j].at)
+		}
+		return timed[i].id < timed[j].id
+	})
+
+	events := make([]realtime.Event, 0, len(timed))
+	for _, item := range timed {
+		events = append(events, item.event)
+	}
+	return events, nil
+}
+
+func activityEvent(row *repositories.ActivityRow) realtime.Event {
+	return realtime.Event{
+		ID:   realtimeEventID(row.CreatedAt, row.ID),
+		Type: RealtimeEventActivity,
+		Data: mapActivityRow(row),
+	}
+}
+
+func notificationEvent(row *repositories.NotificationRow) realtime.Event {
+	return realtime.Event{
+		ID:   realtimeEventID(row.CreatedAt, row.ID),
+		Type: RealtimeEventNotification,
+		Data: mapNotificationRow(row),
+	}
+}
+
+func realtimeEventID(createdAt time.Time, id string) string {
+	return fmt.Sprintf("%d-%s", createdAt.UnixMicro(), id)
+}
+
+func parseRealtimeEventID(value string) (repositories.Cursor, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return repositories.Cursor{}, false
+	}
+
+	micros, id, _ := strings.Cut(value, "-")
+	parsed, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil || parsed <= 0 {
+		return repositories.Cursor{}, false
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return repositories.Cursor{}, false
+	}
+	return repositories.Cursor{CreatedAt: time.UnixMicro(parsed), ID: id}, true
+}
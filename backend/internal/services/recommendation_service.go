@@ -0,0 +1,366 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+
+	"gorm.io/gorm"
+)
+
+const (
+	recommendationLimit          = 50
+	recommendationMinRating      = 7
+	recommendationPopularMin     = 3
+	recommendationRefreshEvery   = 10 * time.Minute
+	recommendationRefreshBatch   = 50
+	recommendationCollabWeight   = 0.6
+	recommendationContentWeight  = 0.4
+	recommendationPopularWeight  = 0.1
+	recommendationCategoryWeight = 0.5
+)
+
+type RecommendationBasis struct {
+	SteamAppID int    `json:"steamAppId"`
+	Name       string `json:"name"`
+	Rating     *int   `json:"rating,omitempty"`
+}
+
+type RecommendationResponse struct {
+	SteamAppID   int                         `json:"steamAppId"`
+	Name         string                      `json:"name"`
+	HeaderImage  string                      `json:"headerImage,omitempty"`
+	CapsuleImage string                      `json:"capsuleImage,omitempty"`
+	StoreURL     string                      `json:"storeUrl,omitempty"`
+	Score        float64                     `json:"score"`
+	Source       models.RecommendationSource `json:"source"`
+	Reason       string                      `json:"reason"`
+	BasedOn      *RecommendationBasis        `json:"basedOn,omitempty"`
+}
+
+type RecommendationPageResponse struct {
+	Data       []*RecommendationResponse `json:"data"`
+	Total      int64                     `json:"total"`
+	Limit      int                       `json:"limit"`
+	Offset     int                       `json:"offset"`
+	ComputedAt time.Time                 `json:"computedAt"`
+}
+
+type RecommendationService struct {
+	recommendationRepository *repositories.RecommendationRepository
+	libraryRepository        *repositories.LibraryRepository
+}
+
+type recommendationCandidate struct {
+	appID         int
+	collaborative float64
+	collabSeed    *repositories.RatedGameRow
+	collabBest    float64
+	content       float64
+	contentSeed   *repositories.RatedGameRow
+	popular       *repositories.PopularGameRow
+}
+
+type gameFeatures map[string]float64
+
+func NewRecommendationService(
+	recommendationRepo *repositories.RecommendationRepository,
+	libraryRepo *repositories.LibraryRepository,
+) *RecommendationService {
+	return &RecommendationService{
+		recommendationRepository: recommendationRepo,
+		libraryRepository:        libraryRepo,
+	}
+}
+
+func (s *RecommendationService) GetRecommendations(userID string, limit, offset int) (*RecommendationPageResponse, error) {
+	run, err := s.recommendationRepository.GetRun(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := s.Recompute(userID); err != nil {
+			return nil, err
+		}
+		run, err = s.recommendationRepository.GetRun(userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.recommendationRepository.ListByUserID(userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.recommendationRepository.CountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &RecommendationPageResponse{
+		Data:       make([]*RecommendationResponse, 0, len(rows)),
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+		ComputedAt: run.ComputedAt,
+	}
+	for i := range rows {
+		page.Data = append(page.Data, mapRecommendationRow(&rows[i]))
+	}
+	return page, nil
+}
+
+func (s *RecommendationService) Run(ctx context.Context) {
+	ticker := time.NewTicker(recommendationRefreshEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshStale()
+		}
+	}
+}
+
+func (s *RecommendationService) refreshStale() {
+	userIDs, err := s.recommendationRepository.ListStaleUserIDs(recommendationRefreshBatch)
+	if err != nil {
+		log.Printf("failed to list stale recommendations: %v", err)
+		return
+	}
+	for _, userID := range userIDs {
+		if err := s.Recompute(userID); err != nil {
+			log.Printf("failed to recompute recommendations for %s: %v", userID, err)
+		}
+	}
+}
+
+func (s *RecommendationService) Recompute(userID string) error {
+	computedAt := time.Now()
+
+	rated, err := s.recommendationRepository.ListRatedGames(userID)
+	if err != nil {
+		return err
+	}
+	ownedIDs, err := s.recommendationRepository.ListOwnedAppIDs(userID)
+	if err != nil {
+		return err
+	}
+	games, err := s.libraryRepository.ListFeatures()
+	if err != nil {
+		return err
+	}
+
+	owned := make(map[int]bool, len(ownedIDs))
+	for _, id := range ownedIDs {
+		owned[id] = true
+	}
+	seeds := make(map[int]*repositories.RatedGameRow)
+	for i := range rated {
+		if rated[i].Rating >= recommendationMinRating {
+			seeds[rated[i].SteamAppID] = &rated[i]
+		}
+	}
+
+	candidates := make(map[int]*recommendationCandidate)
+	candidate := func(appID int) *recommendationCandidate {
+		c, ok := candidates[appID]
+		if !ok {
+			c = &recommendationCandidate{appID: appID}
+			candidates[appID] = c
+		}
+		return c
+	}
+
+	if len(seeds) > 0 {
+		coRated, err := s.recommendationRepository.ListCoRated(userID, recommendationMinRating)
+		if err != nil {
+			return err
+		}
+		for _, row := range coRated {
+			seed := seeds[row.SeedAppID]
+			if seed == nil || owned[row.SteamAppID] {
+				continue
+			}
+			weight := float64(row.Support) * row.AvgRating / 10 * float64(seed.Rating) / 10
+			c := candidate(row.SteamAppID)
+			c.collaborative += weight
+			if weight > c.collabBest {
+				c.collabBest = weight
+				c.collabSeed = seed
+			}
+		}
+
+		seedFeatures := make(map[int]gameFeatures, len(seeds))
+		for _, game := range games {
+			if game.SteamAppID != nil && seeds[*game.SteamAppID] != nil {
+				seedFeatures[*game.SteamAppID] = libraryGameFeatures(game)
+			}
+		}
+		for _, game := range games {
+			if game.SteamAppID == nil || owned[*game.SteamAppID] {
+				continue
+			}
+			features := libraryGameFeatures(game)
+			if len(features) == 0 {
+				continue
+			}
+			for appID, seedFeature := range seedFeatures {
+				seed := seeds[appID]
+				similarity := weightedJaccard(features, seedFeature) * float64(seed.Rating) / 10
+				if similarity <= 0 {
+					continue
+				}
+				c := candidate(*game.SteamAppID)
+				if similarity > c.content {
+					c.content = similarity
+					c.contentSeed = seed
+				}
+			}
+		}
+	}
+
+	if len(candidates) < recommendationLimit {
+		popular, err := s.recommendationRepository.ListPopular(recommendationPopularMin, recommendationLimit*2)
+		if err != nil {
+			return err
+		}
+		for i := range popular {
+			if owned[popular[i].SteamAppID] {
+				continue
+			}
+			if _, exists := candidates[popular[i].SteamAppID]; exists {
+				continue
+			}
+			candidate(popular[i].SteamAppID).popular = &popular[i]
+			if len(candidates) >= recommendationLimit {
+				break
+			}
+		}
+	}
+
+	var maxCollaborative float64
+	for _, c := range candidates {
+		maxCollaborative = math.Max(maxCollaborative, c.collaborative)
+	}
+
+	recommendations := make([]*models.Recommendation, 0, len(candidates))
+	for _, c := range candidates {
+		recommendation := buildRecommendation(userID, c, maxCollaborative, computedAt)
+		if recommendation != nil {
+			recommendations = append(recommendations, recommendation)
+		}
+	}
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].Score == recommendations[j].Score {
+			return recommendations[i].SteamAppID < recommendations[j].SteamAppID
+		}
+		return recommendations[i].Score > recommendations[j].Score
+	})
+	if len(recommendations) > recommendationLimit {
+		recommendations = recommendations[:recommendationLimit]
+	}
+
+	return s.recommendationRepository.ReplaceForUser(userID, recommendations, computedAt)
+}
+
+func buildRecommendation(userID string, c *recommendationCandidate, maxCollaborative float64, computedAt time.Time) *models.Recommendation {
+	recommendation := &models.Recommendation{
+		UserID:     userID,
+		SteamAppID: c.appID,
+		ComputedAt: computedAt,
+	}
+
+	if c.popular != nil {
+		recommendation.Score = c.popular.AvgRating / 10 * recommendationPopularWeight
+		recommendation.Source = models.RecommendationSourcePopular
+		recommendation.Reason = fmt.Sprintf("rated %.1f/10 by %d players", c.popular.AvgRating, c.popular.Ratings)
+		return recommendation
+	}
+
+	var collaborative float64
+	if maxCollaborative > 0 {
+		collaborative = recommendationCollabWeight * c.collaborative / maxCollaborative
+	}
+	content := recommendationContentWeight * c.content
+	if collaborative+content <= 0 {
+		return nil
+	}
+	recommendation.Score = math.Round((collaborative+content)*1000) / 1000
+
+	seed := c.contentSeed
+	recommendation.Source = models.RecommendationSourceContent
+	if c.collabSeed != nil && collaborative >= content {
+		seed = c.collabSeed
+		recommendation.Source = models.RecommendationSourceCollaborative
+	}
+	if seed != nil {
+		appID, rating := seed.SteamAppID, seed.Rating
+		recommendation.BasedOnAppID = &appID
+		recommendation.BasedOnRating = &rating
+		recommendation.Reason = fmt.Sprintf("because you rated %s %d/10", seed.Name, seed.Rating)
+	}
+	return recommendation
+}
+
+func libraryGameFeatures(game *models.LibraryGame) gameFeatures {
+	features := make(gameFeatures)
+	add := func(prefix string, values []string, weight float64) {
+		for _, value := range values {
+			key := strings.ToLower(strings.TrimSpace(value))
+			if key != "" {
+				features[prefix+key] = weight
+			}
+		}
+	}
+	add("genre:", game.Genres, 1)
+	add("tag:", game.Tags, 1)
+	add("category:", game.Categories, recommendationCategoryWeight)
+	return features
+}
+
+func weightedJaccard(a, b gameFeatures) float64 {
+	var intersection, union float64
+	for key, weight := range a {
+		other := b[key]
+		intersection += math.Min(weight, other)
+		union += math.Max(weight, other)
+	}
+	for key, weight := range b {
+		if _, seen := a[key]; !seen {
+			union += weight
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return intersection / union
+}
+
+func mapRecommendationRow(row *repositories.RecommendationRow) *RecommendationResponse {
+	response := &RecommendationResponse{
+		SteamAppID:   row.SteamAppID,
+		Name:         row.Name,
+		HeaderImage:  row.HeaderImage,
+		CapsuleImage: row.CapsuleImage,
+		StoreURL:     row.StoreURL,
+		Score:        row.Score,
+		Source:       row.Source,
+		Reason:       row.Reason,
+	}
+	if row.BasedOnAppID != nil {
+		response.BasedOn = &RecommendationBasis{
+			SteamAppID: *row.BasedOnAppID,
+			Name:       row.BasedOnName,
+			Rating:     row.BasedOnRating,
+		}
+	}
+	return response
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gamecheck/internal/config"
+	"gamecheck/internal/infra/db/repositories"
+)
+
+const retentionBatchSize = 1000
+
+type RetentionService struct {
+	config             config.ActivityRetentionConfig
+	activityRepository *repositories.ActivityRepository
+}
+
+func NewRetentionService(
+	cfg *config.Config,
+	activityRepo *repositories.ActivityRepository,
+) *RetentionService {
+	return &RetentionService{
+		config:             cfg.Activity,
+		activityRepository: activityRepo,
+	}
+}
+
+func (s *RetentionService) Enabled() bool {
+	return s.config.MaxPerUser > 0 || s.config.MaxAge > 0
+}
+
+func (s *RetentionService) Run(ctx context.Context) {
+	if !s.Enabled() {
+		return
+	}
+
+	s.Prune()
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Prune()
+		}
+	}
+}
+
+func (s *RetentionService) Prune() (int64, error) {
+	if !s.Enabled() {
+		return 0, nil
+	}
+
+	var olderThan *time.Time
+	if s.config.MaxAge > 0 {
+		cutoff := time.Now().Add(-s.config.MaxAge)
+		olderThan = &cutoff
+	}
+
+	var total int64
+	for {
+		affected, err := s.activityRepository.Prune(s.config.MaxPerUser, olderThan, s.config.Archive, retentionBatchSize)
+		if err != nil {
+			log.Printf("activity retention failed after %d rows: %v", total, err)
+			return total, err
+		}
+		total += affected
+		if affected < retentionBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		action := "deleted"
+		if s.config.Archive {
+			action = "archived"
+		}
+		log.Printf("activity retention %s %d activities", action, total)
+	}
+	return total, nil
+}
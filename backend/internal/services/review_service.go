@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReviewNotFound        = errors.New("review not found")
+	ErrCannotVoteOwnReview   = errors.New("cannot vote on your own review")
+	ErrCannotReportOwnReview = errors.New("cannot report your own review")
+	ErrAlreadyReported       = errors.New("review already reported")
+	ErrInvalidReportReason   = errors.New("invalid report reason")
+	ErrReportNotFound        = errors.New("report not found")
+	ErrReportAlreadyResolved = errors.New("report already resolved")
+	ErrInvalidReportAction   = errors.New("invalid report action")
+	ErrReviewAuthorBlocked   = errors.New("review author is blocked")
+)
+
+const (
+	ReportActionDismiss      = "dismiss"
+	ReportActionRemoveReview = "remove_review"
+)
+
+type ReviewVoteResponse struct {
+	ReviewID       string `json:"reviewId"`
+	HelpfulCount   int64  `json:"helpfulCount"`
+	UnhelpfulCount int64  `json:"unhelpfulCount"`
+	ViewerVote     *bool  `json:"viewerVote,omitempty"`
+}
+
+type ReviewReportResponse struct {
+	ID           string                    `json:"id"`
+	ReviewID     string                    `json:"reviewId"`
+	Reason       models.ReviewReportReason `json:"reason"`
+	Details      string                    `json:"details,omitempty"`
+	Status       models.ReviewReportStatus `json:"status"`
+	ReportsCount int                       `json:"reportsCount"`
+	Reporter     ActivityUser              `json:"reporter"`
+	Author       ActivityUser              `json:"author"`
+	Review       string                    `json:"review"`
+	Rating       *int                      `json:"rating,omitempty"`
+	GameName     string                    `json:"gameName"`
+	SteamAppID   *int                      `json:"steamAppId,omitempty"`
+	CreatedAt    time.Time                 `json:"createdAt"`
+	ResolvedAt   *time.Time                `json:"resolvedAt,omitempty"`
+}
+
+type ReviewService struct {
+	reviewRepository   *repositories.ReviewRepository
+	progressRepository *repositories.ProgressRepository
+	blockRepository    *repositories.BlockRepository
+}
+
+func NewReviewService(
+	reviewRepo *repositories.ReviewRepository,
+	progressRepo *repositories.ProgressRepository,
+	blockRepo *repositories.BlockRepository,
+) *ReviewService {
+	return &ReviewService{
+		reviewRepository:   reviewRepo,
+		progressRepository: progressRepo,
+		blockRepository:    blockRepo,
+	}
+}
+
+func (s *ReviewService) Vote(reviewID, userID string, helpful bool) (*ReviewVoteResponse, error) {
+	review, err := s.getReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID == userID {
+		return nil, ErrCannotVoteOwnReview
+	}
+	if err := s.checkNotBlocked(review.UserID, userID); err != nil {
+		return nil, err
+	}
+
+	vote := &models.ReviewVote{
+		ProgressID: review.ID,
+		UserID:     userID,
+		Helpful:    helpful,
+	}
+	if err := s.reviewRepository.UpsertVote(vote); err != nil {
+		return nil, err
+	}
+
+	return s.voteResponse(review.ID, &helpful)
+}
+
+func (s *ReviewService) RemoveVote(reviewID, userID string) (*ReviewVoteResponse, error) {
+	review, err := s.getReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewRepository.DeleteVote(review.ID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.voteResponse(review.ID, nil)
+}
+
+func (s *ReviewService) Report(reviewID, reporterID string, reason models.ReviewReportReason, details string) (*models.ReviewReport, error) {
+	if !reason.IsValid() {
+		return nil, ErrInvalidReportReason
+	}
+
+	review, err := s.getReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID == reporterID {
+		return nil, ErrCannotReportOwnReview
+	}
+	if err := s.checkNotBlocked(review.UserID, reporterID); err != nil {
+		return nil, err
+	}
+
+	report := &models.ReviewReport{
+		ID:         uuid.New().String(),
+		ProgressID: review.ID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Details:    strings.TrimSpace(details),
+		Status:     models.ReviewReportStatusPending,
+	}
+	created, err := s.reviewRepository.CreateReport(report)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrAlreadyReported
+	}
+
+	return report, nil
+}
+
+func (s *ReviewService) ListReports(status string, limit, offset int) ([]*ReviewReportResponse, int64, error) {
+	rows, err := s.reviewRepository.ListReports(status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.reviewRepository.CountReports(status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]*ReviewReportResponse, 0, len(rows))
+	for i := range rows {
+		results = append(results, mapReviewReportRow(&rows[i]))
+	}
+
+	return results, total, nil
+}
+
+func (s *ReviewService) ResolveReport(reportID, moderatorID, action string) error {
+	report, err := s.reviewRepository.GetReportByID(reportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReportNotFound
+		}
+		return err
+	}
+
+	if report.Status != models.ReviewReportStatusPending {
+		return ErrReportAlreadyResolved
+	}
+
+	var resolved bool
+	switch action {
+	case ReportActionDismiss:
+		resolved, err = s.reviewRepository.DismissReport(report.ID, moderatorID)
+	case ReportActionRemoveReview:
+		resolved, err = s.reviewRepository.RemoveReview(report.ID, report.ProgressID, moderatorID)
+	default:
+		return ErrInvalidReportAction
+	}
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		return ErrReportAlreadyResolved
+	}
+	return nil
+}
+
+func (s *ReviewService) getReview(reviewID string) (*models.Progress, error) {
+	review, err := s.progressRepository.GetByID(reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, err
+	}
+	if strings.TrimSpace(review.Review) == "" {
+		return nil, ErrReviewNotFound
+	}
+	return review, nil
+}
+
+func (s *ReviewService) checkNotBlocked(authorID, userID string) error {
+	blocked, err := s.blockRepository.IsBlockedEither(authorID, userID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrReviewAuthorBlocked
+	}
+	return nil
+}
+
+func (s *ReviewService) voteResponse(reviewID string, viewerVote *bool) (*ReviewVoteResponse, error) {
+	helpful, unhelpful, err := s.reviewRepository.GetVoteCounts(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	return &ReviewVoteResponse{
+		ReviewID:       reviewID,
+		HelpfulCount:   helpful,
+		UnhelpfulCount: unhelpful,
+		ViewerVote:     viewerVote,
+	}, nil
+}
+
+func mapReviewReportRow(row *repositories.ReviewReportRow) *ReviewReportResponse {
+	return &ReviewReportResponse{
+		ID:           row.ID,
+		ReviewID:     row.ProgressID,
+		Reason:       row.Reason,
+		Details:      row.Details,
+		Status:       row.Status,
+		ReportsCount: row.ReportsCount,
+		Reporter: ActivityUser{
+			ID:          row.ReporterID,
+			DisplayName: row.ReporterDisplayName,
+			AvatarURL:   row.ReporterAvatarURL,
+		},
+		Author: ActivityUser{
+			ID:          row.AuthorID,
+			DisplayName: row.AuthorDisplayName,
+			AvatarURL:   row.AuthorAvatarURL,
+		},
+		Review:     row.Review,
+		Rating:     row.Rating,
+		GameName:   row.GameName,
+		SteamAppID: row.SteamAppID,
+		CreatedAt:  row.CreatedAt,
+		ResolvedAt: row.ResolvedAt,
+	}
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"gamecheck/internal/infra/db/repositories"
+)
+
+const (
+	searchMinQueryLength = 2
+	searchMaxQueryLength = 100
+)
+
+type SearchScope string
+
+const (
+	SearchScopeGames   SearchScope = "games"
+	SearchScopeUsers   SearchScope = "users"
+	SearchScopeReviews SearchScope = "reviews"
+)
+
+type SearchResponse struct {
+	Query   string                         `json:"query"`
+	Games   []repositories.GameSearchRow   `json:"games"`
+	Users   []repositories.UserSearchRow   `json:"users"`
+	Reviews []repositories.ReviewSearchRow `json:"reviews"`
+}
+
+type SearchService struct {
+	searchRepository *repositories.SearchRepository
+}
+
+func NewSearchService(searchRepo *repositories.SearchRepository) *SearchService {
+	return &SearchService{searchRepository: searchRepo}
+}
+
+func (s *SearchService) Search(query, viewerID string, scopes []SearchScope, limit int) (*SearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) > searchMaxQueryLength {
+		query = string([]rune(query)[:searchMaxQueryLength])
+	}
+
+	response := &SearchResponse{
+		Query:   query,
+		Games:   []repositories.GameSearchRow{},
+		Users:   []repositories.UserSearchRow{},
+		Reviews: []repositories.ReviewSearchRow{},
+	}
+	if utf8.RuneCountInString(query) < searchMinQueryLength {
+		return response, nil
+	}
+
+	if hasSearchScope(scopes, SearchScopeGames) {
+		games, err := s.searchRepository.SearchGames(query, limit)
+		if err != nil {
+			return nil, err
+		}
+		if games != nil {
+			response.Games = games
+		}
+	}
+
+	if hasSearchScope(scopes, SearchScopeUsers) {
+		users, err := s.searchRepository.SearchUsers(query, viewerID, limit)
+		if err != nil {
+			return nil, err
+		}
+		if users != nil {
+			response.Users = users
+		}
+	}
+
+	if hasSearchScope(scopes, SearchScopeReviews) {
+		reviews, err := s.searchRepository.SearchReviews(query, viewerID, limit)
+		if err != nil {
+			return nil, err
+		}
+		if reviews != nil {
+			response.Reviews = reviews
+		}
+	}
+
+	return response, nil
+}
+
+func hasSearchScope(scopes []SearchScope, scope SearchScope) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, candidate := range scopes {
+		if candidate == scope {
+			return true
+		}
+	}
+	return false
+}
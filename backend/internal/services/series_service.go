@@ -0,0 +1,472 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+
+	"github.com/google/uuid"
+)
+
+const seriesMaxNumber = 20
+
+var (
+	ErrInvalidSeriesName  = errors.New("series name is required")
+	ErrInvalidSeriesGames = errors.New("gameIds must list distinct library games")
+	ErrSeriesExists       = errors.New("series already exists")
+)
+
+var (
+	seriesSubtitleSeparators = []string{":", " - ", " – ", " — "}
+	seriesNumberRegexp       = regexp.MustCompile(`^(.+?)\s+(\d{1,2}|[IVX]{1,4})$`)
+	seriesRomanNumerals      = map[rune]int{'I': 1, 'V': 5, 'X': 10}
+)
+
+type SeriesSummary struct {
+	models.GameSeries
+	GameCount  int64  `json:"gameCount"`
+	CoverImage string `json:"coverImage,omitempty"`
+}
+
+type SeriesGameResponse struct {
+	models.LibraryGame
+	Position int `json:"position"`
+}
+
+type SeriesDetailResponse struct {
+	models.GameSeries
+	Games []SeriesGameResponse `json:"games"`
+}
+
+type SeriesGameRef struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	HeaderImage string `json:"headerImage,omitempty"`
+	SteamAppID  *int   `json:"steamAppId,omitempty"`
+}
+
+type SeriesPlacement struct {
+	SeriesID  string         `json:"seriesId"`
+	Name      string         `json:"name"`
+	Slug      string         `json:"slug"`
+	Position  int            `json:"position"`
+	GameCount int            `json:"gameCount"`
+	Previous  *SeriesGameRef `json:"previous,omitempty"`
+	Next      *SeriesGameRef `json:"next,omitempty"`
+}
+
+type SeriesProgressEntry struct {
+	Position   int                `json:"position"`
+	Game       SeriesGameRef      `json:"game"`
+	ProgressID *string            `json:"progressId,omitempty"`
+	Status     *models.GameStatus `json:"status,omitempty"`
+	Rating     *int               `json:"rating,omitempty"`
+}
+
+type SeriesProgressResponse struct {
+	SeriesID  string                `json:"seriesId"`
+	Name      string                `json:"name"`
+	UserID    string                `json:"userId"`
+	Total     int                   `json:"total"`
+	Tracked   int                   `json:"tracked"`
+	Completed int                   `json:"completed"`
+	Playing   int                   `json:"playing"`
+	Dropped   int                   `json:"dropped"`
+	Entries   []SeriesProgressEntry `json:"entries"`
+}
+
+type SeriesInput struct {
+	Name        string
+	Description string
+	GameIDs     []string
+}
+
+type SeriesPopulateResult struct {
+	Created  int `json:"created"`
+	Extended int `json:"extended"`
+}
+
+type SeriesService struct {
+	seriesRepository  *repositories.SeriesRepository
+	libraryRepository *repositories.LibraryRepository
+}
+
+func NewSeriesService(
+	seriesRepo *repositories.SeriesRepository,
+	libraryRepo *repositories.LibraryRepository,
+) *SeriesService {
+	return &SeriesService{
+		seriesRepository:  seriesRepo,
+		libraryRepository: libraryRepo,
+	}
+}
+
+func (s *SeriesService) ListSeries(search string, limit, offset int) ([]SeriesSummary, int64, error) {
+	search = strings.TrimSpace(search)
+	rows, err := s.seriesRepository.List(search, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.seriesRepository.Count(search)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]SeriesSummary, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, SeriesSummary{
+			GameSeries: row.GameSeries,
+			GameCount:  row.GameCount,
+			CoverImage: row.CoverImage,
+		})
+	}
+	return results, total, nil
+}
+
+func (s *SeriesService) GetSeries(idOrSlug string) (*SeriesDetailResponse, error) {
+	series, err := s.findSeries(idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.seriesRepository.ListEntries(series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]SeriesGameResponse, 0, len(rows))
+	for i, row := range rows {
+		games = append(games, SeriesGameResponse{
+			LibraryGame: row.LibraryGame,
+			Position:    i + 1,
+		})
+	}
+	return &SeriesDetailResponse{GameSeries: *series, Games: games}, nil
+}
+
+func (s *SeriesService) GetUserProgress(idOrSlug, userID, viewerID string) (*SeriesProgressResponse, error) {
+	series, err := s.findSeries(idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.seriesRepository.ListUserProgress(series.ID, userID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SeriesProgressResponse{
+		SeriesID: series.ID,
+		Name:     series.Name,
+		UserID:   userID,
+		Total:    len(rows),
+		Entries:  make([]SeriesProgressEntry, 0, len(rows)),
+	}
+	for i, row := range rows {
+		response.Entries = append(response.Entries, SeriesProgressEntry{
+			Position: i + 1,
+			Game: SeriesGameRef{
+				ID:          row.GameID,
+				Name:        row.Name,
+				HeaderImage: row.HeaderImage,
+				SteamAppID:  row.SteamAppID,
+			},
+			ProgressID: row.ProgressID,
+			Status:     row.Status,
+			Rating:     row.Rating,
+		})
+		if row.Status == nil {
+			continue
+		}
+		response.Tracked++
+		switch *row.Status {
+		case models.GameStatusCompleted:
+			response.Completed++
+		case models.GameStatusPlaying:
+			response.Playing++
+		case models.GameStatusDropped:
+			response.Dropped++
+		}
+	}
+	return response, nil
+}
+
+func (s *SeriesService) CreateSeries(input SeriesInput) (*SeriesDetailResponse, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, ErrInvalidSeriesName
+	}
+	slug := seriesSlug(name)
+	if slug == "" {
+		return nil, ErrInvalidSeriesName
+	}
+	exists, err := s.seriesRepository.ExistsBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrSeriesExists
+	}
+	if err := s.validateGames(input.GameIDs); err != nil {
+		return nil, err
+	}
+
+	series := &models.GameSeries{
+		Name:        name,
+		Slug:        slug,
+		Description: strings.TrimSpace(input.Description),
+		Source:      models.GameSeriesSourceCurated,
+	}
+	if err := s.seriesRepository.Create(series, input.GameIDs); err != nil {
+		return nil, err
+	}
+	return s.GetSeries(series.ID)
+}
+
+func (s *SeriesService) UpdateSeries(id string, name, description *string) (*SeriesDetailResponse, error) {
+	series, err := s.seriesRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		slug := seriesSlug(trimmed)
+		if slug == "" {
+			return nil, ErrInvalidSeriesName
+		}
+		if slug != series.Slug {
+			exists, err := s.seriesRepository.ExistsBySlug(slug)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, ErrSeriesExists
+			}
+		}
+		series.Name = trimmed
+		series.Slug = slug
+	}
+	if description != nil {
+		series.Description = strings.TrimSpace(*description)
+	}
+	series.Source = models.GameSeriesSourceCurated
+
+	if err := s.seriesRepository.Update(series); err != nil {
+		return nil, err
+	}
+	return s.GetSeries(series.ID)
+}
+
+func (s *SeriesService) SetSeriesGames(id string, gameIDs []string) (*SeriesDetailResponse, error) {
+	series, err := s.seriesRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateGames(gameIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.seriesRepository.ReplaceEntries(series.ID, gameIDs); err != nil {
+		return nil, err
+	}
+	if series.Source != models.GameSeriesSourceCurated {
+		series.Source = models.GameSeriesSourceCurated
+		if err := s.seriesRepository.Update(series); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetSeries(series.ID)
+}
+
+func (s *SeriesService) DeleteSeries(id string) error {
+	return s.seriesRepository.Delete(id)
+}
+
+func (s *SeriesService) PopulateFromSteam() (*SeriesPopulateResult, error) {
+	candidates, err := s.seriesRepository.ListUnassignedGames()
+	if err != nil {
+		return nil, err
+	}
+
+	type seriesCandidate struct {
+		id     string
+		name   string
+		title  string
+		number int
+	}
+
+	groups := make(map[string][]seriesCandidate)
+	var keys []string
+	for _, candidate := range candidates {
+		if candidate.SteamAppID == nil {
+			continue
+		}
+		title, number := parseSeriesTitle(candidate.Name)
+		slug := seriesSlug(title)
+		if slug == "" {
+			continue
+		}
+		if _, ok := groups[slug]; !ok {
+			keys = append(keys, slug)
+		}
+		groups[slug] = append(groups[slug], seriesCandidate{
+			id:     candidate.ID,
+			name:   candidate.Name,
+			title:  title,
+			number: number,
+		})
+	}
+
+	result := &SeriesPopulateResult{}
+	for _, slug := range keys {
+		games := groups[slug]
+		sort.SliceStable(games, func(i, j int) bool {
+			if games[i].number != games[j].number {
+				return games[i].number < games[j].number
+			}
+			if len(games[i].name) != len(games[j].name) {
+				return len(games[i].name) < len(games[j].name)
+			}
+			return games[i].name < games[j].name
+		})
+		ids := make([]string, 0, len(games))
+		for _, game := range games {
+			ids = append(ids, game.id)
+		}
+
+		existing, err := s.seriesRepository.GetBySlug(slug)
+		if err == nil {
+			if err := s.seriesRepository.AppendEntries(existing.ID, ids); err != nil {
+				return nil, err
+			}
+			result.Extended++
+			continue
+		}
+
+		if len(games) < 2 || games[len(games)-1].number < 2 {
+			continue
+		}
+		series := &models.GameSeries{
+			Name:   games[0].title,
+			Slug:   slug,
+			Source: models.GameSeriesSourceSteam,
+		}
+		if err := s.seriesRepository.Create(series, ids); err != nil {
+			return nil, err
+		}
+		result.Created++
+	}
+	return result, nil
+}
+
+func (s *SeriesService) findSeries(idOrSlug string) (*models.GameSeries, error) {
+	if _, err := uuid.Parse(idOrSlug); err == nil {
+		return s.seriesRepository.GetByID(idOrSlug)
+	}
+	return s.seriesRepository.GetBySlug(idOrSlug)
+}
+
+func (s *SeriesService) validateGames(gameIDs []string) error {
+	seen := make(map[string]bool, len(gameIDs))
+	for _, id := range gameIDs {
+		if id == "" || seen[id] {
+			return ErrInvalidSeriesGames
+		}
+		seen[id] = true
+		if _, err := s.libraryRepository.GetByID(id); err != nil {
+			return ErrInvalidSeriesGames
+		}
+	}
+	return nil
+}
+
+func mapSeriesPlacements(rows []repositories.SeriesPlacementRow) []SeriesPlacement {
+	placements := make([]SeriesPlacement, 0, len(rows))
+	for _, row := range rows {
+		placement := SeriesPlacement{
+			SeriesID:  row.SeriesID,
+			Name:      row.SeriesName,
+			Slug:      row.SeriesSlug,
+			Position:  row.Position,
+			GameCount: row.GameCount,
+		}
+		if row.PreviousGameID != "" {
+			placement.Previous = &SeriesGameRef{
+				ID:          row.PreviousGameID,
+				Name:        row.PreviousName,
+				HeaderImage: row.PreviousHeaderImage,
+				SteamAppID:  row.PreviousSteamAppID,
+			}
+		}
+		if row.NextGameID != "" {
+			placement.Next = &SeriesGameRef{
+				ID:          row.NextGameID,
+				Name:        row.NextName,
+				HeaderImage: row.NextHeaderImage,
+				SteamAppID:  row.NextSteamAppID,
+			}
+		}
+		placements = append(placements, placement)
+	}
+	return placements
+}
+
+func parseSeriesTitle(name string) (string, int) {
+	title := strings.NewReplacer("™", "", "®", "", "©", "").Replace(name)
+	for _, separator := range seriesSubtitleSeparators {
+		if index := strings.Index(title, separator); index > 0 {
+			title = title[:index]
+		}
+	}
+	title = strings.TrimSpace(title)
+
+	match := seriesNumberRegexp.FindStringSubmatch(title)
+	if match == nil {
+		return title, 1
+	}
+	number, err := strconv.Atoi(match[2])
+	if err != nil {
+		number = romanToInt(match[2])
+	}
+	if number < 1 || number > seriesMaxNumber {
+		return title, 1
+	}
+	return strings.TrimSpace(match[1]), number
+}
+
+func romanToInt(value string) int {
+	total := 0
+	for i, r := range value {
+		current := seriesRomanNumerals[r]
+		if i+1 < len(value) && current < seriesRomanNumerals[rune(value[i+1])] {
+			total -= current
+		} else {
+			total += current
+		}
+	}
+	return total
+}
+
+func seriesSlug(name string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+			dash = false
+			continue
+		}
+		if builder.Len() > 0 && !dash {
+			builder.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "-")
+}
@@ -1,12 +1,24 @@
 package services
 
 type Services struct {
-	Auth     *AuthService
-	User     *UserService
-	Progress *ProgressService
-	Activity *ActivityService
-	Library  *LibraryService
-	Steam    *SteamService
+	Auth           *AuthService
+	User           *UserService
+	Progress       *ProgressService
+	Activity       *ActivityService
+	Library        *LibraryService
+	Steam          *SteamService
+	Review         *ReviewService
+	Notification   *NotificationService
+	Realtime       *RealtimeService
+	Syndication    *SyndicationService
+	Webhook        *WebhookService
+	Compatibility  *CompatibilityService
+	Recommendation *RecommendationService
+	SimilarGame    *SimilarGameService
+	Search         *SearchService
+	Chart          *ChartService
+	Series         *SeriesService
+	ProgressImport *ProgressImportService
 }
 
 func New(
@@ -16,13 +28,37 @@ func New(
 	activityService *ActivityService,
 	libraryService *LibraryService,
 	steamService *SteamService,
+	reviewService *ReviewService,
+	notificationService *NotificationService,
+	realtimeService *RealtimeService,
+	syndicationService *SyndicationService,
+	webhookService *WebhookService,
+	compatibilityService *CompatibilityService,
+	recommendationService *RecommendationService,
+	similarGameService *SimilarGameService,
+	searchService *SearchService,
+	chartService *ChartService,
+	seriesService *SeriesService,
+	progressImportService *ProgressImportService,
 ) *Services {
 	return &Services{
-		Auth:     authService,
-		User:     userService,
-		Progress: progressService,
-		Activity: activityService,
-		Library:  libraryService,
-		Steam:    steamService,
+		Auth:           authService,
+		User:           userService,
+		Progress:       progressService,
+		Activity:       activityService,
+		Library:        libraryService,
+		Steam:          steamService,
+		Review:         reviewService,
+		Notification:   notificationService,
+		Realtime:       realtimeService,
+		Syndication:    syndicationService,
+		Webhook:        webhookService,
+		Compatibility:  compatibilityService,
+		Recommendation: recommendationService,
+		SimilarGame:    similarGameService,
+		Search:         searchService,
+		Chart:          chartService,
+		Series:         seriesService,
+		ProgressImport: progressImportService,
 	}
 }
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+)
+
+const (
+	similarGamesPerGame       = 30
+	similarGamesMinRating     = 8
+	similarGamesTagWeight     = 0.7
+	similarGamesCoRatedWeight = 0.3
+	similarGamesRefreshEvery  = 6 * time.Hour
+)
+
+type SimilarGameResponse struct {
+	ID           string   `json:"id"`
+	SteamAppID   *int     `json:"steamAppId,omitempty"`
+	Name         string   `json:"name"`
+	HeaderImage  string   `json:"headerImage,omitempty"`
+	CapsuleImage string   `json:"capsuleImage,omitempty"`
+	PrimaryGenre string   `json:"primaryGenre,omitempty"`
+	Genres       []string `json:"genres"`
+	Score        float64  `json:"score"`
+	TagScore     float64  `json:"tagScore"`
+	CoRatedScore float64  `json:"coRatedScore"`
+}
+
+type SimilarGameService struct {
+	similarGameRepository *repositories.SimilarGameRepository
+	libraryRepository     *repositories.LibraryRepository
+	libraryService        *LibraryService
+}
+
+func NewSimilarGameService(
+	similarGameRepo *repositories.SimilarGameRepository,
+	libraryRepo *repositories.LibraryRepository,
+	libraryService *LibraryService,
+) *SimilarGameService {
+	return &SimilarGameService{
+		similarGameRepository: similarGameRepo,
+		libraryRepository:     libraryRepo,
+		libraryService:        libraryService,
+	}
+}
+
+func (s *SimilarGameService) GetSimilarGames(gameID, viewerID string, limit, offset int) ([]*SimilarGameResponse, int64, error) {
+	game, err := s.libraryService.GetVisibleGame(gameID, viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	computed, err := s.similarGameRepository.IsComputed(game.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !computed {
+		if err := s.computeForGame(game); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	rows, err := s.similarGameRepository.ListByGameID(game.ID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.similarGameRepository.CountByGameID(game.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]*SimilarGameResponse, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, &SimilarGameResponse{
+			ID:           row.ID,
+			SteamAppID:   row.SteamAppID,
+			Name:         row.Name,
+			HeaderImage:  row.HeaderImage,
+			CapsuleImage: row.CapsuleImage,
+			PrimaryGenre: row.PrimaryGenre,
+			Genres:       row.Genres,
+			Score:        row.Score,
+			TagScore:     row.TagScore,
+			CoRatedScore: row.CoRatedScore,
+		})
+	}
+	return results, total, nil
+}
+
+func (s *SimilarGameService) Run(ctx context.Context) {
+	ticker := time.NewTicker(similarGamesRefreshEvery)
+	defer ticker.Stop()
+
+	s.RecomputeAll()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RecomputeAll()
+		}
+	}
+}
+
+func (s *SimilarGameService) RecomputeAll() error {
+	games, err := s.libraryRepository.ListFeatures()
+	if err != nil {
+		log.Printf("failed to load library games for similarity: %v", err)
+		return err
+	}
+	pairs, err := s.similarGameRepository.ListCoRatedPairs(similarGamesMinRating, nil)
+	if err != nil {
+		log.Printf("failed to load co-rated games: %v", err)
+		return err
+	}
+	counts, err := s.similarGameRepository.CountHighRated(similarGamesMinRating)
+	if err != nil {
+		log.Printf("failed to count high-rated games: %v", err)
+		return err
+	}
+
+	coRated := groupCoRatedPairs(pairs)
+	features := make(map[string]gameFeatures, len(games))
+	for _, game := range games {
+		features[game.ID] = libraryGameFeatures(game)
+	}
+
+	computedAt := time.Now()
+	for _, game := range games {
+		similar := rankSimilarGames(game, games, features, coRated[intValue(game.SteamAppID)], counts, computedAt)
+		if err := s.similarGameRepository.ReplaceForGame(game.ID, similar, computedAt); err != nil {
+			log.Printf("failed to store similar games for %s: %v", game.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *SimilarGameService) computeForGame(game *models.LibraryGame) error {
+	games, err := s.libraryRepository.ListFeatures()
+	if err != nil {
+		return err
+	}
+	var pairs []repositories.CoRatedPairRow
+	if game.SteamAppID != nil {
+		pairs, err = s.similarGameRepository.ListCoRatedPairs(similarGamesMinRating, game.SteamAppID)
+		if err != nil {
+			return err
+		}
+	}
+	counts, err := s.similarGameRepository.CountHighRated(similarGamesMinRating)
+	if err != nil {
+		return err
+	}
+
+	features := make(map[string]gameFeatures, len(games))
+	for _, candidate := range games {
+		features[candidate.ID] = libraryGameFeatures(candidate)
+	}
+	if _, ok := features[game.ID]; !ok {
+		features[game.ID] = libraryGameFeatures(game)
+	}
+
+	computedAt := time.Now()
+	similar := rankSimilarGames(game, games, features, groupCoRatedPairs(pairs)[intValue(game.SteamAppID)], counts, computedAt)
+	return s.similarGameRepository.ReplaceForGame(game.ID, similar, computedAt)
+}
+
+func groupCoRatedPairs(pairs []repositories.CoRatedPairRow) map[int]map[int]int {
+	grouped := make(map[int]map[int]int)
+	for _, pair := range pairs {
+		if grouped[pair.SteamAppID] == nil {
+			grouped[pair.SteamAppID] = make(map[int]int)
+		}
+		grouped[pair.SteamAppID][pair.OtherAppID] = pair.Users
+	}
+	return grouped
+}
+
+func rankSimilarGames(
+	game *models.LibraryGame,
+	games []*models.LibraryGame,
+	features map[string]gameFeatures,
+	coRated map[int]int,
+	highRatedCounts map[int]int,
+	computedAt time.Time,
+) []*models.SimilarGame {
+	similar := make([]*models.SimilarGame, 0)
+	for _, candidate := range games {
+		if candidate.ID == game.ID {
+			continue
+		}
+
+		tagScore := weightedJaccard(features[game.ID], features[candidate.ID])
+		var coRatedScore float64
+		if users := coRated[intValue(candidate.SteamAppID)]; users > 0 {
+			base, other := highRatedCounts[intValue(game.SteamAppID)], highRatedCounts[intValue(candidate.SteamAppID)]
+			if base > 0 && other > 0 {
+				coRatedScore = math.Min(1, float64(users)/math.Sqrt(float64(base*other)))
+			}
+		}
+
+		score := similarGamesTagWeight*tagScore + similarGamesCoRatedWeight*coRatedScore
+		if score <= 0 {
+			continue
+		}
+		similar = append(similar, &models.SimilarGame{
+			GameID:        game.ID,
+			SimilarGameID: candidate.ID,
+			Score:         math.Round(score*1000) / 1000,
+			TagScore:      math.Round(tagScore*1000) / 1000,
+			CoRatedScore:  math.Round(coRatedScore*1000) / 1000,
+			ComputedAt:    computedAt,
+		})
+	}
+
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Score == similar[j].Score {
+			return similar[i].SimilarGameID < similar[j].SimilarGameID
+		}
+		return similar[i].Score > similar[j].Score
+	})
+	if len(similar) > similarGamesPerGame {
+		similar = similar[:similarGamesPerGame]
+	}
+	return similar
+}
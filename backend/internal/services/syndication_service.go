@@ -0,0 +1,190 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gamecheck/internal/config"
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+	"gamecheck/pkg/feeds"
+
+	"gorm.io/gorm"
+)
+
+const syndicationEntryLimit = 50
+
+var ErrFeedNotFound = errors.New("feed not found")
+
+type SyndicationService struct {
+	config            *config.Config
+	activityService   *ActivityService
+	userRepository    *repositories.UserRepository
+	libraryRepository *repositories.LibraryRepository
+}
+
+func NewSyndicationService(
+	cfg *config.Config,
+	activityService *ActivityService,
+	userRepo *repositories.UserRepository,
+	libraryRepo *repositories.LibraryRepository,
+) *SyndicationService {
+	return &SyndicationService{
+		config:            cfg,
+		activityService:   activityService,
+		userRepository:    userRepo,
+		libraryRepository: libraryRepo,
+	}
+}
+
+func (s *SyndicationService) UserActivityFeed(userID, selfLink string) (*feeds.Feed, error) {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFeedNotFound
+		}
+		return nil, err
+	}
+
+	visible, err := s.userRepository.CanView(userID, "")
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrProfilePrivate
+	}
+
+	page, err := s.activityService.GetUserActivity(userID, "", nil, syndicationEntryLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	profileLink := s.frontendLink("/profile/" + user.ID)
+	feed := &feeds.Feed{
+		ID:       profileLink,
+		Title:    user.DisplayName + " on GameCheck",
+		Subtitle: "Recent game activity of " + user.DisplayName,
+		Link:     profileLink,
+		SelfLink: selfLink,
+		Updated:  user.CreatedAt,
+		Entries:  make([]feeds.Entry, 0, len(page.Data)),
+	}
+
+	for _, activity := range page.Data {
+		if activity.CreatedAt.After(feed.Updated) {
+			feed.Updated = activity.CreatedAt
+		}
+		feed.Entries = append(feed.Entries, feeds.Entry{
+			ID:        "urn:gamecheck:activity:" + activity.ID,
+			Title:     activityTitle(activity),
+			Link:      activityLink(s.config.URLS.Frontend, activity),
+			Author:    activity.User.DisplayName,
+			Published: activity.CreatedAt,
+			Updated:   activity.CreatedAt,
+		})
+	}
+
+	return feed, nil
+}
+
+func (s *SyndicationService) GameReviewsFeed(appID int, selfLink string) (*feeds.Feed, error) {
+	game, err := s.libraryRepository.GetBySteamAppIDWithRedirect(appID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFeedNotFound
+		}
+		return nil, err
+	}
+	if game.ModerationStatus != models.LibraryGameModerationApproved {
+		return nil, ErrFeedNotFound
+	}
+
+	comments, err := s.libraryRepository.GetCommentsByGameID(game.ID, "", "createdAt", syndicationEntryLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	gameLink := s.frontendLink("/library/" + game.ID)
+	if game.SteamAppID != nil {
+		gameLink = s.frontendLink(fmt.Sprintf("/library/app/%d", *game.SteamAppID))
+	}
+	feed := &feeds.Feed{
+		ID:       gameLink,
+		Title:    game.Name + " reviews on GameCheck",
+		Subtitle: "Latest player reviews of " + game.Name,
+		Link:     gameLink,
+		SelfLink: selfLink,
+		Updated:  game.UpdatedAt,
+		Entries:  make([]feeds.Entry, 0, len(comments)),
+	}
+
+	for _, comment := range comments {
+		if comment.CreatedAt.After(feed.Updated) {
+			feed.Updated = comment.CreatedAt
+		}
+		title := comment.User.DisplayName + " reviewed " + game.Name
+		if comment.Rating != nil {
+			title = fmt.Sprintf("%s (%d/10)", title, *comment.Rating)
+		}
+		feed.Entries = append(feed.Entries, feeds.Entry{
+			ID:        "urn:gamecheck:review:" + comment.ID,
+			Title:     title,
+			Link:      gameLink,
+			Content:   comment.Review,
+			Author:    comment.User.DisplayName,
+			Published: comment.CreatedAt,
+			Updated:   comment.CreatedAt,
+		})
+	}
+
+	return feed, nil
+}
+
+func (s *SyndicationService) frontendLink(path string) string {
+	return frontendLink(s.config.URLS.Frontend, path)
+}
+
+func frontendLink(frontendURL, path string) string {
+	return strings.TrimRight(frontendURL, "/") + path
+}
+
+func activityLink(frontendURL string, activity *ActivityResponse) string {
+	if activity.Type == models.ActivityTypeFollow && activity.TargetUserID != nil {
+		return frontendLink(frontendURL, "/profile/"+*activity.TargetUserID)
+	}
+	if activity.Progress != nil && activity.Progress.SteamAppID != nil {
+		return frontendLink(frontendURL, fmt.Sprintf("/library/app/%d", *activity.Progress.SteamAppID))
+	}
+	return frontendLink(frontendURL, "/profile/"+activity.UserID)
+}
+
+func activityTitle(activity *ActivityResponse) string {
+	actor := activity.User.DisplayName
+	game := "a game"
+	if activity.GameName != nil && *activity.GameName != "" {
+		game = *activity.GameName
+	}
+
+	switch activity.Type {
+	case models.ActivityTypeAddGame:
+		return actor + " added " + game
+	case models.ActivityTypeUpdateStatus:
+		if activity.Status != nil {
+			return fmt.Sprintf("%s marked %s as %s", actor, game, *activity.Status)
+		}
+		return actor + " updated " + game
+	case models.ActivityTypeRateGame:
+		if activity.Rating != nil {
+			return fmt.Sprintf("%s rated %s %d/10", actor, game, *activity.Rating)
+		}
+		return actor + " reviewed " + game
+	case models.ActivityTypeFollow:
+		if activity.TargetUser != nil {
+			return actor + " followed " + activity.TargetUser.DisplayName
+		}
+		return actor + " followed a player"
+	default:
+		return actor + " was active"
+	}
+}
@@ -1,25 +1,52 @@
 package services
 
 import (
+	"errors"
+	"time"
+
 	"gamecheck/internal/domain/models"
 	"gamecheck/internal/infra/db/repositories"
+
+	"gorm.io/gorm"
 )
 
+var (
+	ErrUserNotFound             = errors.New("user not found")
+	ErrInvalidProfileVisibility = errors.New("invalid profile visibility")
+	ErrProfilePrivate           = errors.New("profile is private")
+	ErrCannotBlockSelf          = errors.New("cannot block yourself")
+	ErrCannotMuteSelf           = errors.New("cannot mute yourself")
+)
+
+type RelatedUserResponse struct {
+	User      ActivityUser `json:"user"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
 type UserService struct {
 	userRepository         *repositories.UserRepository
 	subscriptionRepository *repositories.SubscriptionRepository
 	activityRepository     *repositories.ActivityRepository
+	reviewRepository       *repositories.ReviewRepository
+	blockRepository        *repositories.BlockRepository
+	muteRepository         *repositories.MuteRepository
 }
 
 func NewUserService(
 	userRepo *repositories.UserRepository,
 	subscriptionRepo *repositories.SubscriptionRepository,
 	activityRepo *repositories.ActivityRepository,
+	reviewRepo *repositories.ReviewRepository,
+	blockRepo *repositories.BlockRepository,
+	muteRepo *repositories.MuteRepository,
 ) *UserService {
 	return &UserService{
 		userRepository:         userRepo,
 		subscriptionRepository: subscriptionRepo,
 		activityRepository:     activityRepo,
+		reviewRepository:       reviewRepo,
+		blockRepository:        blockRepo,
+		muteRepository:         muteRepo,
 	}
 }
 
@@ -47,8 +74,8 @@ func (s *UserService) UpdateUser(user *models.User) error {
 	return s.userRepository.Update(user)
 }
 
-func (s *UserService) SearchUsers(query string, limit int) ([]*models.User, error) {
-	return s.userRepository.Search(query, limit)
+func (s *UserService) SearchUsers(query, viewerID string, limit int) ([]*models.User, error) {
+	return s.userRepository.Search(query, viewerID, limit)
 }
 
 func (s *UserService) GetUserWithStats(id string) (*models.User, error) {
@@ -57,39 +84,210 @@ func (s *UserService) GetUserWithStats(id string) (*models.User, error) {
 		return nil, err
 	}
 
-	followersCount, _ := s.subscriptionRepository.GetFollowersCount(id)
-	followingCount, _ := s.subscriptionRepository.GetFollowingCount(id)
-
-	user.FollowersCount = int(followersCount)
-	user.FollowingCount = int(followingCount)
+	if counts, err := s.subscriptionRepository.GetFollowCounts(id); err == nil {
+		user.FollowersCount = int(counts.Followers)
+		user.FollowingCount = int(counts.Following)
+	}
+	helpfulScore, _ := s.reviewRepository.GetHelpfulScoreByUserID(id)
+	user.HelpfulScore = int(helpfulScore)
 
 	return user, nil
 }
 
 func (s *UserService) GetUserProfile(userID, currentUserID string) (*models.User, error) {
+	if currentUserID != "" && currentUserID != userID {
+		blockedBy, err := s.blockRepository.IsBlocked(userID, currentUserID)
+		if err != nil {
+			return nil, err
+		}
+		if blockedBy {
+			return nil, ErrUserNotFound
+		}
+	}
+
 	user, err := s.GetUserWithStats(userID)
 	if err != nil {
 		return nil, err
 	}
 
+	if currentUserID != "" && currentUserID != userID {
+		user.IsBlocked, _ = s.blockRepository.IsBlocked(currentUserID, userID)
+		user.IsMuted, _ = s.muteRepository.IsMuted(currentUserID, userID)
+	}
+
 	if currentUserID != "" {
 		isFollowing, _ := s.subscriptionRepository.IsFollowing(currentUserID, userID)
 		user.IsFollowing = isFollowing
+		if !isFollowing {
+			if sub, err := s.subscriptionRepository.GetByUsers(currentUserID, userID); err == nil {
+				user.FollowRequested = sub.Status == models.SubscriptionStatusPending
+			}
+		}
+	}
+
+	user.CanViewContent = !user.IsBlocked && canViewProfile(user, currentUserID, user.IsFollowing)
+	if !user.CanViewContent {
+		user.FollowersCount = 0
+		user.FollowingCount = 0
+		user.GamesCount = 0
+		user.TotalPlaytime = 0
+		user.AverageRating = 0
+		user.HelpfulScore = 0
 	}
 
 	return user, nil
 }
 
-func (s *UserService) ListUsers(limit, offset int, sortBy, order string) ([]*models.User, int64, error) {
-	users, err := s.userRepository.List(limit, offset, sortBy, order)
+func (s *UserService) CanViewProfile(ownerID, viewerID string) (bool, error) {
+	owner, err := s.userRepository.GetByID(ownerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, ErrUserNotFound
+		}
+		return false, err
+	}
+
+	if viewerID != "" && viewerID != ownerID {
+		blocked, err := s.blockRepository.IsBlockedEither(ownerID, viewerID)
+		if err != nil {
+			return false, err
+		}
+		if blocked {
+			return false, ErrUserNotFound
+		}
+	}
+
+	if canViewProfile(owner, viewerID, false) {
+		return true, nil
+	}
+	if viewerID == "" {
+		return false, nil
+	}
+	return s.subscriptionRepository.IsFollowing(viewerID, ownerID)
+}
+
+func (s *UserService) UpdateProfileVisibility(user *models.User, visibility models.ProfileVisibility) error {
+	if !visibility.IsValid() {
+		return ErrInvalidProfileVisibility
+	}
+
+	previous := user.ProfileVisibility
+	user.ProfileVisibility = visibility
+	if err := s.userRepository.Update(user); err != nil {
+		return err
+	}
+
+	if previous == models.ProfileVisibilityPrivate && visibility != models.ProfileVisibilityPrivate {
+		return s.subscriptionRepository.ApproveAllPending(user.ID)
+	}
+	return nil
+}
+
+func (s *UserService) IsBlockedEither(userID, otherID string) (bool, error) {
+	return s.blockRepository.IsBlockedEither(userID, otherID)
+}
+
+func (s *UserService) BlockUser(userID, targetID string) error {
+	if userID == targetID {
+		return ErrCannotBlockSelf
+	}
+	if err := s.ensureUserExists(targetID); err != nil {
+		return err
+	}
+	return s.blockRepository.Block(userID, targetID)
+}
+
+func (s *UserService) UnblockUser(userID, targetID string) error {
+	return s.blockRepository.Unblock(userID, targetID)
+}
+
+func (s *UserService) ListBlockedUsers(userID string, limit, offset int) ([]*RelatedUserResponse, int64, error) {
+	rows, err := s.blockRepository.ListBlocked(userID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
+	total, err := s.blockRepository.CountBlocked(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mapRelatedUserRows(rows), total, nil
+}
 
-	total, err := s.userRepository.Count()
+func (s *UserService) MuteUser(userID, targetID string) error {
+	if userID == targetID {
+		return ErrCannotMuteSelf
+	}
+	if err := s.ensureUserExists(targetID); err != nil {
+		return err
+	}
+	return s.muteRepository.Mute(userID, targetID)
+}
+
+func (s *UserService) UnmuteUser(userID, targetID string) error {
+	return s.muteRepository.Unmute(userID, targetID)
+}
+
+func (s *UserService) ListMutedUsers(userID string, limit, offset int) ([]*RelatedUserResponse, int64, error) {
+	rows, err := s.muteRepository.ListMuted(userID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
+	total, err := s.muteRepository.CountMuted(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mapRelatedUserRows(rows), total, nil
+}
+
+func (s *UserService) ensureUserExists(id string) error {
+	if _, err := s.userRepository.GetByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func mapRelatedUserRows(rows []repositories.RelatedUserRow) []*RelatedUserResponse {
+	results := make([]*RelatedUserResponse, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, &RelatedUserResponse{
+			User: ActivityUser{
+				ID:          row.UserID,
+				DisplayName: row.DisplayName,
+				AvatarURL:   row.AvatarURL,
+			},
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return results
+}
+
+func canViewProfile(owner *models.User, viewerID string, isFollowing bool) bool {
+	if owner.ID == viewerID || owner.ProfileVisibility == models.ProfileVisibilityPublic || owner.ProfileVisibility == "" {
+		return true
+	}
+	return viewerID != "" && isFollowing
+}
+
+func (s *UserService) ListUsers(cursor *repositories.Cursor, limit, offset int, sortBy, order string) ([]*models.User, int64, *string, error) {
+	users, err := s.userRepository.List(cursor, limit+1, offset, sortBy, order)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var nextCursor *string
+	if len(users) > limit {
+		users = users[:limit]
+		next := repositories.EncodeCursor(repositories.UserCursor(users[len(users)-1], sortBy))
+		nextCursor = &next
+	}
+
+	total, err := s.userRepository.Count()
+	if err != nil {
+		return nil, 0, nil, err
+	}
 
-	return users, total, nil
+	return users, total, nextCursor, nil
 }
@@ -0,0 +1,652 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gamecheck/internal/config"
+	"gamecheck/internal/domain/models"
+	"gamecheck/internal/infra/db/repositories"
+	"gamecheck/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	webhookMaxPerUser         = 5
+	webhookMaxAttempts        = 6
+	webhookBaseBackoff        = time.Minute
+	webhookPollInterval       = 5 * time.Second
+	webhookPurgeInterval      = time.Hour
+	webhookClaimBatch         = 20
+	webhookClaimLease         = 5 * time.Minute
+	webhookRequestTimeout     = 10 * time.Second
+	webhookDeliveredRetention = 7 * 24 * time.Hour
+	webhookErrorBodyLimit     = 512
+	webhookUserAgent          = "GameCheck-Webhooks/1.0"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrWebhookLimitReached     = errors.New("webhook limit reached")
+	ErrInvalidWebhookURL       = errors.New("invalid webhook url")
+	ErrInvalidWebhookEvents    = errors.New("invalid webhook events")
+	ErrInvalidWebhookFormat    = errors.New("invalid webhook format")
+	ErrWebhookDeliveryNotDead  = errors.New("only dead deliveries can be retried")
+)
+
+var discordWebhookHosts = map[string]bool{
+	"discord.com":        true,
+	"discordapp.com":     true,
+	"ptb.discord.com":    true,
+	"canary.discord.com": true,
+}
+
+type WebhookResponse struct {
+	ID        string               `json:"id"`
+	URL       string               `json:"url"`
+	Events    []string             `json:"events"`
+	Format    models.WebhookFormat `json:"format"`
+	Active    bool                 `json:"active"`
+	Secret    string               `json:"secret,omitempty"`
+	CreatedAt time.Time            `json:"createdAt"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+type WebhookInput struct {
+	URL    *string
+	Events []string
+	Format *string
+	Active *bool
+}
+
+type WebhookDeliveryPage struct {
+	Data   []models.WebhookDelivery `json:"data"`
+	Total  int64                    `json:"total"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
+}
+
+type webhookPayload struct {
+	ID        string            `json:"id"`
+	Event     string            `json:"event"`
+	WebhookID string            `json:"webhookId"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Activity  *ActivityResponse `json:"activity,omitempty"`
+	Actor     *webhookActor     `json:"actor,omitempty"`
+}
+
+type webhookActor struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	AvatarURL   string `json:"avatarUrl"`
+	DiscordTag  string `json:"discordTag,omitempty"`
+	ProfileURL  string `json:"profileUrl"`
+}
+
+type discordPayload struct {
+	Username string         `json:"username"`
+	Content  string         `json:"content,omitempty"`
+	Embeds   []discordEmbed `json:"embeds,omitempty"`
+}
+
+type discordEmbed struct {
+	Title     string            `json:"title"`
+	URL       string            `json:"url,omitempty"`
+	Color     int               `json:"color"`
+	Timestamp string            `json:"timestamp"`
+	Author    *discordAuthor    `json:"author,omitempty"`
+	Thumbnail *discordThumbnail `json:"thumbnail,omitempty"`
+	Footer    *discordFooter    `json:"footer,omitempty"`
+}
+
+type discordAuthor struct {
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+type discordThumbnail struct {
+	URL string `json:"url"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+type WebhookService struct {
+	config             *config.Config
+	webhookRepository  *repositories.WebhookRepository
+	activityRepository *repositories.ActivityRepository
+	userRepository     *repositories.UserRepository
+	client             *http.Client
+}
+
+func NewWebhookService(
+	cfg *config.Config,
+	webhookRepo *repositories.WebhookRepository,
+	activityRepo *repositories.ActivityRepository,
+	userRepo *repositories.UserRepository,
+) *WebhookService {
+	dialer := &net.Dialer{Timeout: webhookRequestTimeout}
+	if cfg.Env == "production" {
+		dialer.Control = rejectInternalAddress
+	}
+
+	return &WebhookService{
+		config:             cfg,
+		webhookRepository:  webhookRepo,
+		activityRepository: activityRepo,
+		userRepository:     userRepo,
+		client: &http.Client{
+			Timeout:   webhookRequestTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *WebhookService) List(userID string) ([]*WebhookResponse, error) {
+	webhooks, err := s.webhookRepository.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*WebhookResponse, 0, len(webhooks))
+	for i := range webhooks {
+		results = append(results, mapWebhook(&webhooks[i], false))
+	}
+	return results, nil
+}
+
+func (s *WebhookService) Create(userID string, input WebhookInput) (*WebhookResponse, error) {
+	count, err := s.webhookRepository.CountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= webhookMaxPerUser {
+		return nil, ErrWebhookLimitReached
+	}
+
+	secret, err := utils.RandomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &models.Webhook{
+		UserID: userID,
+		Secret: "whsec_" + secret,
+		Active: true,
+	}
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+	if input.URL == nil {
+		return nil, ErrInvalidWebhookURL
+	}
+	if input.Events == nil {
+		input.Events = []string{}
+		for _, event := range models.WebhookEvents {
+			input.Events = append(input.Events, string(event))
+		}
+	}
+	if err := s.applyInput(webhook, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepository.Create(webhook); err != nil {
+		return nil, err
+	}
+	return mapWebhook(webhook, true), nil
+}
+
+func (s *WebhookService) Update(id, userID string, input WebhookInput) (*WebhookResponse, error) {
+	webhook, err := s.getOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+	if err := s.applyInput(webhook, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepository.Update(webhook); err != nil {
+		return nil, err
+	}
+	return mapWebhook(webhook, false), nil
+}
+
+func (s *WebhookService) Delete(id, userID string) error {
+	if _, err := s.getOwned(id, userID); err != nil {
+		return err
+	}
+	return s.webhookRepository.Delete(id)
+}
+
+func (s *WebhookService) RotateSecret(id, userID string) (*WebhookResponse, error) {
+	webhook, err := s.getOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.RandomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = "whsec_" + secret
+
+	if err := s.webhookRepository.Update(webhook); err != nil {
+		return nil, err
+	}
+	return mapWebhook(webhook, true), nil
+}
+
+func (s *WebhookService) Test(id, userID string) (*models.WebhookDelivery, error) {
+	webhook, err := s.getOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID: webhook.ID,
+		Event:     models.WebhookEventPing,
+		Status:    models.WebhookDeliveryPending,
+	}
+	if err := s.webhookRepository.CreateDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	s.deliver(webhook, delivery, false)
+	if err := s.webhookRepository.UpdateDelivery(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (s *WebhookService) ListDeliveries(id, userID, status string, limit, offset int) (*WebhookDeliveryPage, error) {
+	if _, err := s.getOwned(id, userID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepository.ListDeliveries(id, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.webhookRepository.CountDeliveries(id, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if deliveries == nil {
+		deliveries = []models.WebhookDelivery{}
+	}
+	return &WebhookDeliveryPage{
+		Data:   deliveries,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+func (s *WebhookService) RetryDelivery(id, deliveryID, userID string) (*models.WebhookDelivery, error) {
+	if _, err := s.getOwned(id, userID); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.webhookRepository.GetDelivery(deliveryID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	if delivery.Status != models.WebhookDeliveryDead {
+		return nil, ErrWebhookDeliveryNotDead
+	}
+
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.LastError = ""
+	if err := s.webhookRepository.UpdateDelivery(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (s *WebhookService) Run(ctx context.Context) {
+	poll := time.NewTicker(webhookPollInterval)
+	defer poll.Stop()
+	purge := time.NewTicker(webhookPurgeInterval)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+			s.processDue()
+		case <-purge.C:
+			if _, err := s.webhookRepository.PurgeDeliveredBefore(time.Now().Add(-webhookDeliveredRetention)); err != nil {
+				log.Printf("failed to purge webhook deliveries: %v", err)
+			}
+		}
+	}
+}
+
+func (s *WebhookService) processDue() {
+	deliveries, err := s.webhookRepository.ClaimDueDeliveries(webhookClaimBatch, webhookClaimLease)
+	if err != nil {
+		log.Printf("failed to claim webhook deliveries: %v", err)
+		return
+	}
+
+	webhooks := make(map[string]*models.Webhook)
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = s.webhookRepository.GetByID(delivery.WebhookID)
+			if err != nil {
+				log.Printf("failed to load webhook %s: %v", delivery.WebhookID, err)
+				continue
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if webhook.Active {
+			s.deliver(webhook, delivery, true)
+		} else {
+			delivery.Status = models.WebhookDeliveryDead
+			delivery.LastError = "webhook disabled"
+		}
+
+		if err := s.webhookRepository.UpdateDelivery(delivery); err != nil {
+			log.Printf("failed to update webhook delivery %s: %v", delivery.ID, err)
+		}
+	}
+}
+
+func (s *WebhookService) deliver(webhook *models.Webhook, delivery *models.WebhookDelivery, retry bool) {
+	delivery.Attempts++
+
+	if delivery.Payload == "" {
+		payload, err := s.buildPayload(webhook, delivery)
+		if err != nil {
+			delivery.Status = models.WebhookDeliveryDead
+			delivery.LastError = err.Error()
+			return
+		}
+		delivery.Payload = string(payload)
+	}
+
+	statusCode, err := s.send(webhook, delivery)
+	if statusCode != 0 {
+		delivery.ResponseStatus = &statusCode
+	}
+	if err == nil {
+		now := time.Now()
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	permanent := statusCode >= 400 && statusCode < 500 && statusCode != http.StatusRequestTimeout && statusCode != http.StatusTooManyRequests
+	if !retry || permanent || delivery.Attempts >= webhookMaxAttempts {
+		delivery.Status = models.WebhookDeliveryDead
+		return
+	}
+
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.NextAttemptAt = time.Now().Add(webhookBaseBackoff << (delivery.Attempts - 1))
+}
+
+func (s *WebhookService) send(webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set("X-GameCheck-Event", delivery.Event)
+	req.Header.Set("X-GameCheck-Delivery", delivery.ID)
+	req.Header.Set("X-GameCheck-Timestamp", timestamp)
+	req.Header.Set("X-GameCheck-Signature", "sha256="+utils.SignHMAC(webhook.Secret, timestamp+"."+delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+	return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func (s *WebhookService) buildPayload(webhook *models.Webhook, delivery *models.WebhookDelivery) ([]byte, error) {
+	if delivery.Event == models.WebhookEventPing {
+		if webhook.Format == models.WebhookFormatDiscord {
+			return json.Marshal(discordPayload{
+				Username: "GameCheck",
+				Content:  "GameCheck webhook is connected",
+			})
+		}
+		return json.Marshal(webhookPayload{
+			ID:        delivery.ID,
+			Event:     delivery.Event,
+			WebhookID: webhook.ID,
+			CreatedAt: delivery.CreatedAt,
+		})
+	}
+
+	if delivery.ActivityID == nil {
+		return nil, errors.New("activity no longer exists")
+	}
+	row, err := s.activityRepository.GetRowByID(*delivery.ActivityID, webhook.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("activity no longer exists")
+		}
+		return nil, err
+	}
+	activity := mapActivityRow(row)
+
+	actor := &webhookActor{
+		ID:          activity.User.ID,
+		DisplayName: activity.User.DisplayName,
+		AvatarURL:   activity.User.AvatarURL,
+		ProfileURL:  frontendLink(s.config.URLS.Frontend, "/profile/"+activity.UserID),
+	}
+	if user, err := s.userRepository.GetByID(activity.UserID); err == nil {
+		actor.DiscordTag = user.DiscordTag
+	}
+
+	if webhook.Format == models.WebhookFormatDiscord {
+		return json.Marshal(s.discordEmbed(activity, actor))
+	}
+	return json.Marshal(webhookPayload{
+		ID:        delivery.ID,
+		Event:     delivery.Event,
+		WebhookID: webhook.ID,
+		CreatedAt: delivery.CreatedAt,
+		Activity:  activity,
+		Actor:     actor,
+	})
+}
+
+func (s *WebhookService) discordEmbed(activity *ActivityResponse, actor *webhookActor) discordPayload {
+	authorName := actor.DisplayName
+	if actor.DiscordTag != "" {
+		authorName = fmt.Sprintf("%s (@%s)", actor.DisplayName, actor.DiscordTag)
+	}
+
+	embed := discordEmbed{
+		Title:     activityTitle(activity),
+		URL:       activityLink(s.config.URLS.Frontend, activity),
+		Color:     discordColor(activity.Type),
+		Timestamp: activity.CreatedAt.UTC().Format(time.RFC3339),
+		Author: &discordAuthor{
+			Name:    authorName,
+			URL:     actor.ProfileURL,
+			IconURL: actor.AvatarURL,
+		},
+		Footer: &discordFooter{Text: "GameCheck"},
+	}
+	if activity.Progress != nil && activity.Progress.SteamIconURL != "" {
+		embed.Thumbnail = &discordThumbnail{URL: activity.Progress.SteamIconURL}
+	}
+
+	return discordPayload{
+		Username: "GameCheck",
+		Embeds:   []discordEmbed{embed},
+	}
+}
+
+func (s *WebhookService) applyInput(webhook *models.Webhook, input WebhookInput) error {
+	if input.URL != nil {
+		parsed, err := s.validateURL(*input.URL)
+		if err != nil {
+			return err
+		}
+		webhook.URL = parsed.String()
+		if input.Format == nil {
+			webhook.Format = models.WebhookFormatJSON
+			if isDiscordWebhookURL(parsed) {
+				webhook.Format = models.WebhookFormatDiscord
+			}
+		}
+	}
+
+	if input.Format != nil {
+		format := models.WebhookFormat(*input.Format)
+		if !format.IsValid() {
+			return ErrInvalidWebhookFormat
+		}
+		parsed, _ := url.Parse(webhook.URL)
+		if format == models.WebhookFormatDiscord && (parsed == nil || !isDiscordWebhookURL(parsed)) {
+			return ErrInvalidWebhookFormat
+		}
+		webhook.Format = format
+	}
+
+	if input.Events != nil {
+		events := make([]string, 0, len(input.Events))
+		seen := make(map[string]bool)
+		for _, event := range input.Events {
+			if !models.IsWebhookEvent(event) {
+				return ErrInvalidWebhookEvents
+			}
+			if !seen[event] {
+				seen[event] = true
+				events = append(events, event)
+			}
+		}
+		if len(events) == 0 {
+			return ErrInvalidWebhookEvents
+		}
+		webhook.Events = events
+	}
+
+	return nil
+}
+
+func (s *WebhookService) validateURL(raw string) (*url.URL, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" || parsed.User != nil {
+		return nil, ErrInvalidWebhookURL
+	}
+	if parsed.Scheme != "https" && (parsed.Scheme != "http" || s.config.Env == "production") {
+		return nil, ErrInvalidWebhookURL
+	}
+	return parsed, nil
+}
+
+func (s *WebhookService) getOwned(id, userID string) (*models.Webhook, error) {
+	webhook, err := s.webhookRepository.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	if webhook.UserID != userID {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func mapWebhook(webhook *models.Webhook, includeSecret bool) *WebhookResponse {
+	response := &WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		Format:    webhook.Format,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+	if includeSecret {
+		response.Secret = webhook.Secret
+	}
+	return response
+}
+
+func isDiscordWebhookURL(parsed *url.URL) bool {
+	return discordWebhookHosts[strings.ToLower(parsed.Hostname())] && strings.HasPrefix(parsed.Path, "/api/webhooks/")
+}
+
+func discordColor(activityType models.ActivityType) int {
+	switch activityType {
+	case models.ActivityTypeAddGame:
+		return 0x5865F2
+	case models.ActivityTypeUpdateStatus:
+		return 0xFEE75C
+	case models.ActivityTypeRateGame:
+		return 0x57F287
+	case models.ActivityTypeFollow:
+		return 0xEB459E
+	default:
+		return 0x99AAB5
+	}
+}
+
+func rejectInternalAddress(network, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+		return fmt.Errorf("webhook target %s is not allowed", host)
+	}
+	return nil
+}
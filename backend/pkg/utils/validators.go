@@ -49,3 +49,14 @@ func ValidateRating(rating int) error {
 	}
 	return nil
 }
+
+func ValidateComment(body string) error {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return fmt.Errorf("comment must not be empty")
+	}
+	if len(trimmed) > 500 {
+		return fmt.Errorf("comment must not exceed 500 characters")
+	}
+	return nil
+}
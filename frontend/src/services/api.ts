@@ -345,10 +345,24 @@ const libraryApi = {
 }
 
 const subscriptionsApi = {
-  getFollowers: (userId: string) =>
-    axiosInstance.get<User[]>(`/subscriptions/${userId}/followers`),
-  getFollowing: (userId: string) =>
-    axiosInstance.get<User[]>(`/subscriptions/${userId}/following`),
+  getFollowers: (userId: string, limit = 50, offset = 0) =>
+    axiosInstance
+      .get<{
+        data: User[]
+        total: number
+        limit: number
+        offset: number
+      }>(`/subscriptions/${userId}/followers`, { params: { limit, offset } })
+      .then(res => ({ ...res, data: res.data.data })),
+  getFollowing: (userId: string, limit = 50, offset = 0) =>
+    axiosInstance
+      .get<{
+        data: User[]
+        total: number
+        limit: number
+        offset: number
+      }>(`/subscriptions/${userId}/following`, { params: { limit, offset } })
+      .then(res => ({ ...res, data: res.data.data })),
   follow: (userId: string) =>
     axiosInstance.post(`/subscriptions/follow/${userId}`),
   unfollow: (userId: string) =>